@@ -3,7 +3,9 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -47,6 +49,23 @@ type Config struct {
 	// Default: 3 seconds
 	WriteTimeout time.Duration
 
+	// SentinelAddrs lists Redis Sentinel addresses. When set, OpenWithConfig
+	// connects through a failover client that follows the master named by
+	// SentinelMasterName, and Addr is ignored.
+	SentinelAddrs []string
+
+	// SentinelMasterName is the master set name monitored by the sentinels.
+	// Required with SentinelAddrs.
+	SentinelMasterName string
+
+	// SentinelPassword authenticates to the sentinels; Password still
+	// authenticates to the master and replicas.
+	SentinelPassword string
+
+	// ClusterAddrs lists Redis Cluster seed nodes. When set, OpenWithConfig
+	// builds a cluster client, and Addr and DB are not used.
+	ClusterAddrs []string
+
 	// KeyPrefix is prepended to all keys.
 	KeyPrefix string
 }
@@ -66,9 +85,15 @@ func DefaultConfig() Config {
 
 // Cache wraps Redis client with convenience methods.
 type Cache struct {
-	client *redis.Client
+	client redis.UniversalClient
 	prefix string
 	flight singleflight.Group
+	local  *localCache
+
+	// now and rand drive FetchJSON's early expiration; nil means time.Now
+	// and math/rand/v2.
+	now  func() time.Time
+	rand func() float64
 }
 
 // Open opens a Redis connection with default settings.
@@ -131,6 +156,9 @@ func OpenURLWithPrefix(ctx context.Context, url, prefix string) (*Cache, error)
 //	    KeyPrefix: "myapp:",
 //	})
 func OpenWithConfig(ctx context.Context, cfg Config) (*Cache, error) {
+	if err := validateTopology(cfg); err != nil {
+		return nil, err
+	}
 	if cfg.URL != "" {
 		c, err := OpenURL(ctx, cfg.URL)
 		if err != nil {
@@ -140,16 +168,10 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*Cache, error) {
 		return c, nil
 	}
 
-	client := redis.NewClient(&redis.Options{
-		Addr:         cfg.Addr,
-		Password:     cfg.Password,
-		DB:           cfg.DB,
-		PoolSize:     cfg.PoolSize,
-		MinIdleConns: cfg.MinIdleConns,
-		DialTimeout:  cfg.DialTimeout,
-		ReadTimeout:  cfg.ReadTimeout,
-		WriteTimeout: cfg.WriteTimeout,
-	})
+	client, err := newClient(cfg)
+	if err != nil {
+		return nil, err
+	}
 
 	if err := client.Ping(ctx).Err(); err != nil {
 		client.Close()
@@ -159,8 +181,70 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*Cache, error) {
 	return &Cache{client: client, prefix: cfg.KeyPrefix}, nil
 }
 
-// Client returns the underlying Redis client.
-func (c *Cache) Client() *redis.Client {
+// validateTopology rejects configs that select more than one of URL,
+// SentinelAddrs, and ClusterAddrs.
+func validateTopology(cfg Config) error {
+	selected := 0
+	for _, set := range []bool{cfg.URL != "", len(cfg.SentinelAddrs) > 0, len(cfg.ClusterAddrs) > 0} {
+		if set {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return errors.New("cache config: URL, SentinelAddrs, and ClusterAddrs are mutually exclusive")
+	}
+	if len(cfg.ClusterAddrs) > 0 && cfg.DB != 0 {
+		return errors.New("cache config: Redis Cluster supports only DB 0")
+	}
+	return nil
+}
+
+// newClient builds a standalone client, a Sentinel failover client when
+// cfg.SentinelAddrs is set, or a cluster client when cfg.ClusterAddrs is set.
+func newClient(cfg Config) (redis.UniversalClient, error) {
+	if len(cfg.ClusterAddrs) > 0 {
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:        cfg.ClusterAddrs,
+			Password:     cfg.Password,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		}), nil
+	}
+	if len(cfg.SentinelAddrs) == 0 {
+		return redis.NewClient(&redis.Options{
+			Addr:         cfg.Addr,
+			Password:     cfg.Password,
+			DB:           cfg.DB,
+			PoolSize:     cfg.PoolSize,
+			MinIdleConns: cfg.MinIdleConns,
+			DialTimeout:  cfg.DialTimeout,
+			ReadTimeout:  cfg.ReadTimeout,
+			WriteTimeout: cfg.WriteTimeout,
+		}), nil
+	}
+	if cfg.SentinelMasterName == "" {
+		return nil, errors.New("cache config: SentinelMasterName is required with SentinelAddrs")
+	}
+	return redis.NewFailoverClient(&redis.FailoverOptions{
+		MasterName:       cfg.SentinelMasterName,
+		SentinelAddrs:    cfg.SentinelAddrs,
+		SentinelPassword: cfg.SentinelPassword,
+		Password:         cfg.Password,
+		DB:               cfg.DB,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+	}), nil
+}
+
+// Client returns the underlying Redis client: a *redis.Client for standalone
+// and Sentinel configs, or a *redis.ClusterClient for ClusterAddrs.
+func (c *Cache) Client() redis.UniversalClient {
 	return c.client
 }
 
@@ -179,7 +263,7 @@ func (c *Cache) Key(key string) string {
 
 // GetJSON retrieves and unmarshals a JSON value.
 func (c *Cache) GetJSON(ctx context.Context, key string, dest interface{}) error {
-	data, err := c.client.Get(ctx, c.Key(key)).Bytes()
+	data, err := c.get(ctx, key)
 	if err != nil {
 		return err
 	}
@@ -192,7 +276,7 @@ func (c *Cache) SetJSON(ctx context.Context, key string, value interface{}, ttl
 	if err != nil {
 		return fmt.Errorf("failed to marshal value: %w", err)
 	}
-	return c.client.Set(ctx, c.Key(key), data, ttl).Err()
+	return c.set(ctx, key, data, ttl)
 }
 
 // Remember gets a value or sets it using the provided function.
@@ -203,9 +287,9 @@ func (c *Cache) SetJSON(ctx context.Context, key string, value interface{}, ttl
 //	    return db.GetUser(ctx, 123)
 //	})
 func (c *Cache) Remember(ctx context.Context, key string, ttl time.Duration, fn func() (interface{}, error)) (string, error) {
-	val, err := c.client.Get(ctx, c.Key(key)).Result()
+	val, err := c.get(ctx, key)
 	if err == nil {
-		return val, nil
+		return string(val), nil
 	}
 	if err != redis.Nil {
 		return "", err
@@ -214,8 +298,8 @@ func (c *Cache) Remember(ctx context.Context, key string, ttl time.Duration, fn
 	// Use singleflight to prevent cache stampede
 	v, err, _ := c.flight.Do(key, func() (interface{}, error) {
 		// Double-check cache after acquiring the flight
-		if val, err := c.client.Get(ctx, c.Key(key)).Result(); err == nil {
-			return val, nil
+		if val, err := c.get(ctx, key); err == nil {
+			return string(val), nil
 		}
 
 		fnResult, fnErr := fn()
@@ -237,7 +321,7 @@ func (c *Cache) Remember(ctx context.Context, key string, ttl time.Duration, fn
 			strVal = string(data)
 		}
 
-		if err := c.client.Set(ctx, c.Key(key), strVal, ttl).Err(); err != nil {
+		if err := c.set(ctx, key, []byte(strVal), ttl); err != nil {
 			return nil, err
 		}
 
@@ -303,6 +387,81 @@ func (c *Cache) RememberJSON(ctx context.Context, key string, ttl time.Duration,
 	return nil
 }
 
+// Remember is the typed form of Cache.RememberJSON. It returns the JSON value
+// stored at key, or calls fn, stores its result with ttl, and returns it.
+// A missing or undecodable value is recomputed, and concurrent misses for
+// the same key in this process share one call to fn.
+//
+// Example:
+//
+//	user, err := cache.Remember(ctx, c, "user:123", time.Hour, func() (User, error) {
+//	    return db.GetUser(ctx, 123)
+//	})
+func Remember[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, fn func() (T, error)) (T, error) {
+	var zero T
+	if fn == nil {
+		return zero, fmt.Errorf("remember %q: nil callback", key)
+	}
+	if value, err := getTyped[T](ctx, c, key); err == nil {
+		return value, nil
+	} else if !IsNil(err) {
+		return zero, err
+	}
+
+	result, err, _ := c.flight.Do(flightKey[T]("remember", key), func() (interface{}, error) {
+		// Double-check cache after acquiring the flight
+		if value, err := getTyped[T](ctx, c, key); err == nil {
+			return value, nil
+		}
+		value, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		if err := c.SetJSON(ctx, key, value, ttl); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+	if err != nil {
+		return zero, err
+	}
+	return sharedResult[T](key, result)
+}
+
+// flightKey scopes a singleflight key to T, so callers asking for different
+// types at the same key never share a result.
+func flightKey[T any](kind, key string) string {
+	return kind + ":" + reflect.TypeFor[T]().String() + ":" + key
+}
+
+// sharedResult converts a singleflight result back to T. Type names are not
+// unique across packages, so a mismatch is reported rather than zeroed.
+func sharedResult[T any](key string, result any) (T, error) {
+	var zero T
+	if result == nil {
+		return zero, nil
+	}
+	value, ok := result.(T)
+	if !ok {
+		return zero, fmt.Errorf("cache key %q: shared result is %T, want %s", key, result, reflect.TypeFor[T]())
+	}
+	return value, nil
+}
+
+// getTyped decodes the JSON value at key, reporting an undecodable value as
+// redis.Nil so callers recompute it.
+func getTyped[T any](ctx context.Context, c *Cache, key string) (T, error) {
+	var value T
+	data, err := c.get(ctx, key)
+	if err != nil {
+		return value, err
+	}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return value, redis.Nil
+	}
+	return value, nil
+}
+
 // IsNil returns true if the error is a cache miss.
 func IsNil(err error) bool {
 	return err == redis.Nil
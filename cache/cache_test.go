@@ -2,6 +2,9 @@ package cache
 
 import (
 	"errors"
+	"fmt"
+	"os"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -122,6 +125,11 @@ func TestIsNil(t *testing.T) {
 			err:  redis.Nil,
 			want: true,
 		},
+		{
+			name: "redis error returns false",
+			err:  errors.New("dial tcp: connection refused"),
+			want: false,
+		},
 		{
 			name: "nil error returns false",
 			err:  nil,
@@ -144,3 +152,99 @@ func TestIsNil(t *testing.T) {
 		})
 	}
 }
+
+// openTestCache connects to the Redis named by GOKART_TEST_REDIS_URL with a
+// per-test key prefix, skipping the test when no server is configured.
+func openTestCache(t *testing.T) *Cache {
+	t.Helper()
+
+	url := os.Getenv("GOKART_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("set GOKART_TEST_REDIS_URL to run Redis integration tests")
+	}
+	prefix := fmt.Sprintf("gokart-test:%s:%d:", t.Name(), time.Now().UnixNano())
+	c, err := OpenURLWithPrefix(t.Context(), url, prefix)
+	if err != nil {
+		t.Fatalf("open test cache: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := c.Close(); err != nil {
+			t.Errorf("close test cache: %v", err)
+		}
+	})
+	return c
+}
+
+func TestRememberRejectsNilCallback(t *testing.T) {
+	t.Parallel()
+
+	_, err := Remember[int](t.Context(), &Cache{}, "key", time.Minute, nil)
+	if err == nil {
+		t.Fatal("Remember with nil callback: want error, got nil")
+	}
+}
+
+func TestRememberCallsFnOnceForConcurrentMisses(t *testing.T) {
+	c := openTestCache(t)
+
+	type user struct{ Name string }
+	var calls atomic.Int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fn := func() (user, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+		}
+		<-release
+		return user{Name: "ada"}, nil
+	}
+
+	results := make(chan user, 2)
+	errs := make(chan error, 2)
+	remember := func() {
+		u, err := Remember(t.Context(), c, "user:1", time.Minute, fn)
+		results <- u
+		errs <- err
+	}
+	go remember()
+	<-started
+	go remember()
+	time.Sleep(50 * time.Millisecond) // let the second caller join the flight
+	close(release)
+
+	for range 2 {
+		if err := <-errs; err != nil {
+			t.Fatalf("Remember: %v", err)
+		}
+		if u := <-results; u.Name != "ada" {
+			t.Fatalf("Remember = %+v, want ada", u)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("fn called %d times, want 1", got)
+	}
+
+	cached, err := Remember(t.Context(), c, "user:1", time.Minute, func() (user, error) {
+		return user{}, errors.New("fn called on a hit")
+	})
+	if err != nil || cached.Name != "ada" {
+		t.Fatalf("Remember hit = %+v, %v", cached, err)
+	}
+}
+
+func TestSharedResultRejectsWrongType(t *testing.T) {
+	t.Parallel()
+
+	if _, err := sharedResult[int]("key", "not an int"); err == nil {
+		t.Fatal("sharedResult accepted a string for int")
+	}
+	if got, err := sharedResult[int]("key", 7); err != nil || got != 7 {
+		t.Fatalf("sharedResult = %d, %v; want 7, nil", got, err)
+	}
+	if got, err := sharedResult[any]("key", nil); err != nil || got != nil {
+		t.Fatalf("sharedResult of nil = %v, %v; want nil, nil", got, err)
+	}
+	if flightKey[int]("remember", "k") == flightKey[string]("remember", "k") {
+		t.Fatal("flight keys for different types collide")
+	}
+}
@@ -0,0 +1,235 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// IdempotencyKeyHeader is the request header NewIdempotencyMiddleware reads.
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// DefaultMaxIdempotentBodyBytes caps the request body NewIdempotencyMiddleware
+// buffers to hash and the response body it buffers to store, so neither a
+// client nor a handler can make the server hold an arbitrary body in memory.
+// Use NewIdempotencyMiddlewareWithLimit for a different cap.
+const DefaultMaxIdempotentBodyBytes int64 = 10 * 1024 * 1024
+
+// idempotentResponse is the stored form of a completed request. Status 0
+// marks a request that is still running. RequestHash is the SHA-256 of the
+// request body that claimed the key.
+type idempotentResponse struct {
+	RequestHash string      `json:"request_hash"`
+	Status      int         `json:"status"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+// NewIdempotencyMiddleware replays the stored response for requests that
+// repeat an Idempotency-Key header within ttl. Requests without the header
+// pass through unchanged.
+//
+// identity returns the caller a key belongs to, typically the authenticated
+// user or API client ID, so one caller cannot replay another's response.
+// Requests for which identity is nil or returns "" pass through unchanged.
+//
+// The request body is read in full to hash it, up to
+// DefaultMaxIdempotentBodyBytes; a larger body receives 413 Request Entity
+// Too Large without running the handler. A response body larger than the
+// same cap is sent to the client but not stored, and the key is released so
+// a retry runs the handler again.
+//
+// The first request with a key claims it atomically and runs the handler;
+// its status, body, and the headers the handler set are stored for ttl and
+// replayed with an Idempotent-Replayed: true header. Headers already present
+// when the middleware runs, such as X-Request-Id from outer middleware, are
+// not stored, so a replay carries its own. Set-Cookie is never stored. A repeat
+// that arrives while the first is still running receives 409 Conflict, and
+// a repeat with a different request body receives 422 Unprocessable Entity.
+// Responses with a 5xx status are not stored, so the client can retry. Keys
+// are scoped to the caller, method, path, and query string, and Redis errors
+// fail closed with 503 Service Unavailable.
+//
+// Example:
+//
+//	router.With(cache.NewIdempotencyMiddleware(c, 24*time.Hour, accountID)).Post("/payments", createPayment)
+func NewIdempotencyMiddleware(c *Cache, ttl time.Duration, identity func(*http.Request) string) func(http.Handler) http.Handler {
+	return NewIdempotencyMiddlewareWithLimit(c, ttl, identity, DefaultMaxIdempotentBodyBytes)
+}
+
+// NewIdempotencyMiddlewareWithLimit is NewIdempotencyMiddleware with an
+// explicit cap on the buffered request and response bodies. A non-positive
+// limit uses DefaultMaxIdempotentBodyBytes.
+func NewIdempotencyMiddlewareWithLimit(c *Cache, ttl time.Duration, identity func(*http.Request) string, limit int64) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = DefaultMaxIdempotentBodyBytes
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			idempotencyKey := r.Header.Get(IdempotencyKeyHeader)
+			if idempotencyKey == "" || identity == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+			caller := identity(r)
+			if caller == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				var tooLarge *http.MaxBytesError
+				if errors.As(err, &tooLarge) {
+					http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+					return
+				}
+				http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+			requestHash := sha256.Sum256(body)
+
+			ctx := r.Context()
+			key := c.Key("idempotency:" + idempotencyScope(caller, r.Method, r.URL.Path, r.URL.RawQuery, idempotencyKey))
+			claim := idempotentResponse{RequestHash: hex.EncodeToString(requestHash[:])}
+
+			pending, _ := json.Marshal(claim)
+			claimed, err := c.client.SetNX(ctx, key, pending, ttl).Result()
+			if err != nil {
+				http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+				return
+			}
+			if !claimed {
+				replayIdempotentResponse(w, r, c, key, claim.RequestHash)
+				return
+			}
+
+			// Storing the result must outlive a client disconnect.
+			storeCtx := context.WithoutCancel(ctx)
+			outer := w.Header().Clone()
+			rec := &idempotencyRecorder{ResponseWriter: w, status: http.StatusOK, limit: limit}
+			completed := false
+			defer func() {
+				// Release the claim when the handler panics or fails, or the
+				// response is too large to store, so the client can retry.
+				if !completed {
+					c.client.Del(storeCtx, key)
+				}
+			}()
+			next.ServeHTTP(rec, r)
+			if rec.status >= http.StatusInternalServerError || rec.overflow {
+				return
+			}
+			claim.Status, claim.Header, claim.Body = rec.status, handlerHeader(outer, w.Header()), rec.body.Bytes()
+			// Cookies belong to the session that made the first request.
+			claim.Header.Del("Set-Cookie")
+			stored, err := json.Marshal(claim)
+			if err != nil {
+				return
+			}
+			if err := c.client.Set(storeCtx, key, stored, ttl).Err(); err == nil {
+				completed = true
+			}
+		})
+	}
+}
+
+// handlerHeader returns the headers in current that the handler added or
+// changed since outer was captured.
+func handlerHeader(outer, current http.Header) http.Header {
+	own := http.Header{}
+	for name, values := range current {
+		if !slices.Equal(outer[name], values) {
+			own[name] = slices.Clone(values)
+		}
+	}
+	return own
+}
+
+// idempotencyScope hashes the parts of a replay key so a caller, path, or
+// client key containing the separator cannot collide with another's.
+func idempotencyScope(parts ...string) string {
+	h := sha256.New()
+	for _, part := range parts {
+		_, _ = io.WriteString(h, part)
+		_, _ = h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func replayIdempotentResponse(w http.ResponseWriter, r *http.Request, c *Cache, key, requestHash string) {
+	data, err := c.client.Get(r.Context(), key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		// The claim expired or was released between SETNX and GET.
+		http.Error(w, "idempotent request was not completed; retry", http.StatusConflict)
+		return
+	}
+	var stored idempotentResponse
+	if err == nil {
+		err = json.Unmarshal(data, &stored)
+	}
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		return
+	}
+	if stored.RequestHash != requestHash {
+		http.Error(w, "Idempotency-Key was reused with a different request body", http.StatusUnprocessableEntity)
+		return
+	}
+	if stored.Status == 0 {
+		http.Error(w, "idempotent request is still in progress", http.StatusConflict)
+		return
+	}
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+	w.Header().Set("Idempotent-Replayed", "true")
+	w.WriteHeader(stored.Status)
+	_, _ = w.Write(stored.Body)
+}
+
+// idempotencyRecorder copies the status and body written through it. It
+// stops copying the body and sets overflow once the body passes limit bytes.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+	limit       int64
+	overflow    bool
+}
+
+func (r *idempotencyRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status, r.wroteHeader = status, true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *idempotencyRecorder) Write(p []byte) (int, error) {
+	r.wroteHeader = true
+	if !r.overflow {
+		if int64(r.body.Len()+len(p)) > r.limit {
+			r.overflow = true
+			r.body = bytes.Buffer{}
+		} else {
+			r.body.Write(p)
+		}
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
@@ -0,0 +1,256 @@
+package cache
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+const testCallerHeader = "X-Test-Caller"
+
+func testCaller(r *http.Request) string {
+	return r.Header.Get(testCallerHeader)
+}
+
+func TestIdempotencyMiddlewarePassesThroughWithoutKey(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddleware(&Cache{}, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	for range 2 {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/payments", nil))
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("handler calls = %d, want 2", got)
+	}
+}
+
+func TestIdempotencyMiddlewareReplaysResponse(t *testing.T) {
+	c := openTestCache(t)
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddleware(c, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"payment":%d}`, n)
+	}))
+	post := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+		req.Header.Set(IdempotencyKeyHeader, key)
+		req.Header.Set(testCallerHeader, "alice")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := post("abc")
+	second := post("abc")
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("handler calls = %d, want 1", got)
+	}
+	if second.Code != http.StatusCreated || second.Body.String() != first.Body.String() {
+		t.Fatalf("replay = %d %q, want %d %q", second.Code, second.Body, first.Code, first.Body)
+	}
+	if second.Header().Get("Content-Type") != "application/json" || second.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("replay headers = %v", second.Header())
+	}
+
+	if other := post("def"); other.Body.String() != `{"payment":2}` {
+		t.Fatalf("new key body = %q, want a fresh response", other.Body)
+	}
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreServerErrors(t *testing.T) {
+	c := openTestCache(t)
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddleware(c, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			http.Error(w, "unavailable", http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	for _, want := range []int{http.StatusBadGateway, http.StatusCreated} {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(IdempotencyKeyHeader, "retry")
+		req.Header.Set(testCallerHeader, "alice")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Fatalf("status = %d, want %d", rec.Code, want)
+		}
+	}
+}
+
+func TestIdempotencyMiddlewarePassesThroughWithoutCaller(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddleware(&Cache{}, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+	req.Header.Set(IdempotencyKeyHeader, "abc")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated || calls.Load() != 1 {
+		t.Fatalf("status = %d, calls = %d; want pass-through", rec.Code, calls.Load())
+	}
+}
+
+func TestIdempotencyMiddlewareScopesKeysToCallerAndBody(t *testing.T) {
+	c := openTestCache(t)
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddleware(c, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := calls.Add(1)
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: fmt.Sprint(n)})
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"payment":%d}`, n)
+	}))
+	post := func(caller, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(body))
+		req.Header.Set(IdempotencyKeyHeader, "shared")
+		req.Header.Set(testCallerHeader, caller)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	post("alice", `{"amount":100}`)
+	if bob := post("bob", `{"amount":100}`); bob.Body.String() != `{"payment":2}` {
+		t.Fatalf("other caller body = %q, want a fresh response", bob.Body)
+	}
+	replay := post("alice", `{"amount":100}`)
+	if replay.Body.String() != `{"payment":1}` {
+		t.Fatalf("replay body = %q, want the first response", replay.Body)
+	}
+	if cookies := replay.Header().Values("Set-Cookie"); len(cookies) != 0 {
+		t.Fatalf("replay Set-Cookie = %v, want none", cookies)
+	}
+	if changed := post("alice", `{"amount":200}`); changed.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("changed body status = %d, want %d", changed.Code, http.StatusUnprocessableEntity)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("handler calls = %d, want 2", got)
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsOversizedBody(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddlewareWithLimit(&Cache{}, time.Minute, testCaller, 8)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	req := httptest.NewRequest(http.MethodPost, "/payments", strings.NewReader(`{"amount":100}`))
+	req.Header.Set(IdempotencyKeyHeader, "abc")
+	req.Header.Set(testCallerHeader, "alice")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge || calls.Load() != 0 {
+		t.Fatalf("status = %d, calls = %d; want 413 without running the handler", rec.Code, calls.Load())
+	}
+}
+
+func TestIdempotencyMiddlewareReplaysOnlyHandlerHeaders(t *testing.T) {
+	c := openTestCache(t)
+
+	var requests atomic.Int32
+	requestID := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Request-Id", fmt.Sprint(requests.Add(1)))
+			next.ServeHTTP(w, r)
+		})
+	}
+	handler := requestID(NewIdempotencyMiddleware(c, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Location", "/payments/1")
+		w.WriteHeader(http.StatusCreated)
+	})))
+	post := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payments", nil)
+		req.Header.Set(IdempotencyKeyHeader, "headers")
+		req.Header.Set(testCallerHeader, "alice")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	post()
+	replay := post()
+	if replay.Header().Get("Idempotent-Replayed") != "true" || replay.Header().Get("Location") != "/payments/1" {
+		t.Fatalf("replay headers = %v, want the handler's Location", replay.Header())
+	}
+	if got := replay.Header().Get("X-Request-Id"); got != "2" {
+		t.Fatalf("replay X-Request-Id = %q, want the replay's own 2", got)
+	}
+}
+
+func TestIdempotencyMiddlewareDefaultsNonPositiveLimit(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddlewareWithLimit(&Cache{}, time.Minute, testCaller, 0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+	}))
+	body := strings.NewReader(strings.Repeat("x", int(DefaultMaxIdempotentBodyBytes)+1))
+	req := httptest.NewRequest(http.MethodPost, "/payments", body)
+	req.Header.Set(IdempotencyKeyHeader, "abc")
+	req.Header.Set(testCallerHeader, "alice")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge || calls.Load() != 0 {
+		t.Fatalf("status = %d, calls = %d; want 413 from the default cap", rec.Code, calls.Load())
+	}
+}
+
+func TestIdempotencyMiddlewareDoesNotStoreOversizedResponses(t *testing.T) {
+	c := openTestCache(t)
+
+	var calls atomic.Int32
+	handler := NewIdempotencyMiddlewareWithLimit(c, time.Minute, testCaller, 16)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, "%032d", calls.Add(1))
+	}))
+	for want := 1; want <= 2; want++ {
+		req := httptest.NewRequest(http.MethodPost, "/exports", nil)
+		req.Header.Set(IdempotencyKeyHeader, "large")
+		req.Header.Set(testCallerHeader, "alice")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || rec.Body.String() != fmt.Sprintf("%032d", want) {
+			t.Fatalf("response %d = %d %q, want the full body from a fresh run", want, rec.Code, rec.Body)
+		}
+	}
+}
+
+func TestIdempotencyMiddlewareScopesKeysToQuery(t *testing.T) {
+	c := openTestCache(t)
+
+	handler := NewIdempotencyMiddleware(c, time.Minute, testCaller)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, r.URL.Query().Get("account"))
+	}))
+	for _, account := range []string{"1", "2"} {
+		req := httptest.NewRequest(http.MethodPost, "/payments?account="+account, nil)
+		req.Header.Set(IdempotencyKeyHeader, "shared")
+		req.Header.Set(testCallerHeader, "alice")
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK || rec.Body.String() != account {
+			t.Fatalf("account %s response = %d %q, want its own", account, rec.Code, rec.Body)
+		}
+	}
+}
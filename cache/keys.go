@@ -0,0 +1,141 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// scanBatchSize is the COUNT hint for each SCAN call and the number of
+// deletes sent per pipeline.
+const scanBatchSize = 500
+
+// globEscaper escapes the glob metacharacters Redis MATCH understands, so a
+// key prefix always matches literally.
+var globEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+
+// Keys returns the logical keys matching the glob pattern, without the
+// configured prefix. It iterates with SCAN, never KEYS, so Redis is not
+// blocked; keys written during the scan may or may not be included. On a
+// cluster every master is scanned.
+//
+// Example:
+//
+//	keys, err := c.Keys(ctx, "session:*")
+func (c *Cache) Keys(ctx context.Context, pattern string) ([]string, error) {
+	var (
+		mu   sync.Mutex
+		keys []string
+	)
+	err := c.scan(ctx, c.matchPattern(pattern), func(_ context.Context, _ *redis.Client, batch []string) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, key := range batch {
+			keys = append(keys, strings.TrimPrefix(key, c.prefix))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("keys %q: %w", pattern, err)
+	}
+	return keys, nil
+}
+
+// DeletePattern deletes the keys matching the glob pattern, scanning and
+// deleting in pipelined batches, and returns how many were deleted. Deleted
+// keys are also dropped from the local fallback.
+//
+// Example:
+//
+//	n, err := c.DeletePattern(ctx, "user:42:*")
+func (c *Cache) DeletePattern(ctx context.Context, pattern string) (int64, error) {
+	deleted, err := c.deleteMatching(ctx, c.matchPattern(pattern))
+	if err != nil {
+		return deleted, fmt.Errorf("delete pattern %q: %w", pattern, err)
+	}
+	return deleted, nil
+}
+
+// FlushPrefix deletes every key under the configured prefix. It refuses to
+// run without a prefix, which would delete the whole database.
+func (c *Cache) FlushPrefix(ctx context.Context) (int64, error) {
+	if c.prefix == "" {
+		return 0, errors.New("flush prefix: cache has no key prefix")
+	}
+	deleted, err := c.deleteMatching(ctx, globEscaper.Replace(c.prefix)+"*")
+	if err != nil {
+		return deleted, fmt.Errorf("flush prefix: %w", err)
+	}
+	return deleted, nil
+}
+
+// matchPattern prefixes pattern with the literal, escaped key prefix.
+func (c *Cache) matchPattern(pattern string) string {
+	return globEscaper.Replace(c.prefix) + pattern
+}
+
+func (c *Cache) deleteMatching(ctx context.Context, match string) (int64, error) {
+	var (
+		mu      sync.Mutex
+		deleted int64
+	)
+	err := c.scan(ctx, match, func(ctx context.Context, node *redis.Client, batch []string) error {
+		// One DEL per key keeps cluster pipelines free of CROSSSLOT errors.
+		cmds, err := node.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, key := range batch {
+				pipe.Del(ctx, key)
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if c.local != nil {
+			for _, key := range batch {
+				c.local.remove(strings.TrimPrefix(key, c.prefix))
+			}
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		for _, cmd := range cmds {
+			deleted += cmd.(*redis.IntCmd).Val()
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+// scan calls fn with each batch of raw keys matching match. A cluster client
+// scans every master concurrently, so fn must be safe for concurrent use.
+func (c *Cache) scan(ctx context.Context, match string, fn func(context.Context, *redis.Client, []string) error) error {
+	scanNode := func(ctx context.Context, node *redis.Client) error {
+		var cursor uint64
+		for {
+			batch, next, err := node.Scan(ctx, cursor, match, scanBatchSize).Result()
+			if err != nil {
+				return err
+			}
+			if len(batch) > 0 {
+				if err := fn(ctx, node, batch); err != nil {
+					return err
+				}
+			}
+			if next == 0 {
+				return nil
+			}
+			cursor = next
+		}
+	}
+	switch client := c.client.(type) {
+	case *redis.ClusterClient:
+		return client.ForEachMaster(ctx, scanNode)
+	case *redis.Client:
+		return scanNode(ctx, client)
+	default:
+		return fmt.Errorf("unsupported client %T", c.client)
+	}
+}
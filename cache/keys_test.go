@@ -0,0 +1,57 @@
+package cache
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMatchPatternEscapesPrefix(t *testing.T) {
+	t.Parallel()
+
+	c := &Cache{prefix: "app[1]*:"}
+	if got, want := c.matchPattern("user:*"), `app\[1\]\*:user:*`; got != want {
+		t.Errorf("matchPattern = %q, want %q", got, want)
+	}
+}
+
+func TestFlushPrefixRequiresPrefix(t *testing.T) {
+	t.Parallel()
+
+	if _, err := (&Cache{}).FlushPrefix(t.Context()); err == nil {
+		t.Fatal("FlushPrefix without prefix: want error, got nil")
+	}
+}
+
+func TestKeysDeletePatternAndFlushPrefix(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	for _, key := range []string{"item:1", "item:2", "item:3", "other"} {
+		if err := c.SetJSON(ctx, key, key, time.Minute); err != nil {
+			t.Fatalf("SetJSON(%q): %v", key, err)
+		}
+	}
+
+	keys, err := c.Keys(ctx, "item:*")
+	if err != nil {
+		t.Fatalf("Keys: %v", err)
+	}
+	slices.Sort(keys)
+	if want := []string{"item:1", "item:2", "item:3"}; !slices.Equal(keys, want) {
+		t.Fatalf("Keys = %v, want %v", keys, want)
+	}
+
+	deleted, err := c.DeletePattern(ctx, "item:*")
+	if err != nil || deleted != 3 {
+		t.Fatalf("DeletePattern = %d, %v; want 3", deleted, err)
+	}
+	if keys, err := c.Keys(ctx, "item:*"); err != nil || len(keys) != 0 {
+		t.Fatalf("Keys after delete = %v, %v", keys, err)
+	}
+
+	deleted, err = c.FlushPrefix(ctx)
+	if err != nil || deleted != 1 {
+		t.Fatalf("FlushPrefix = %d, %v; want 1", deleted, err)
+	}
+}
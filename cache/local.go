@@ -0,0 +1,159 @@
+package cache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// LRUStats reports local fallback activity.
+type LRUStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// OpenWithLocalFallback opens Redis like OpenWithConfig and keeps up to
+// localSize recently used values in process for at most localTTL. GetJSON,
+// SetJSON, MGet, MSet, and Remember read the local copy first. Writes reach
+// the local copy only after Redis accepts them, so a failed write never
+// leaves a value that Redis does not hold. While Redis is unavailable, reads
+// are served from the local copy only until its local TTL expires; after that
+// they return the Redis error. Keys changed through Client are not seen
+// locally until their local copy expires.
+//
+// Example:
+//
+//	c, err := cache.OpenWithLocalFallback(ctx, cfg, 1000, 30*time.Second)
+func OpenWithLocalFallback(ctx context.Context, cfg Config, localSize int, localTTL time.Duration) (*Cache, error) {
+	if localSize <= 0 {
+		return nil, fmt.Errorf("local fallback: size must be positive")
+	}
+	if localTTL <= 0 {
+		return nil, fmt.Errorf("local fallback: ttl must be positive")
+	}
+	c, err := OpenWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.local = newLocalCache(localSize, localTTL)
+	return c, nil
+}
+
+// LocalStats returns local fallback counters. It is zero when the cache has no
+// local fallback.
+func (c *Cache) LocalStats() LRUStats {
+	if c.local == nil {
+		return LRUStats{}
+	}
+	return c.local.stats()
+}
+
+// get reads key through the local fallback when one is configured.
+func (c *Cache) get(ctx context.Context, key string) ([]byte, error) {
+	if c.local != nil {
+		if data, ok := c.local.get(key); ok {
+			return data, nil
+		}
+	}
+	data, err := c.client.Get(ctx, c.Key(key)).Bytes()
+	if err != nil {
+		return nil, err
+	}
+	if c.local != nil {
+		c.local.add(key, data, 0)
+	}
+	return data, nil
+}
+
+// set writes key to Redis and then, when Redis accepted it, to the local
+// fallback.
+func (c *Cache) set(ctx context.Context, key string, data []byte, ttl time.Duration) error {
+	if err := c.client.Set(ctx, c.Key(key), data, ttl).Err(); err != nil {
+		return err
+	}
+	if c.local != nil {
+		c.local.add(key, data, ttl)
+	}
+	return nil
+}
+
+type localCache struct {
+	mu      sync.Mutex
+	size    int
+	ttl     time.Duration
+	order   *list.List
+	entries map[string]*list.Element
+	counts  LRUStats
+	now     func() time.Time
+}
+
+type localEntry struct {
+	key       string
+	data      []byte
+	expiresAt time.Time
+}
+
+func newLocalCache(size int, ttl time.Duration) *localCache {
+	return &localCache{size: size, ttl: ttl, order: list.New(), entries: make(map[string]*list.Element), now: time.Now}
+}
+
+func (l *localCache) get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	elem, ok := l.entries[key]
+	if !ok {
+		l.counts.Misses++
+		return nil, false
+	}
+	entry := elem.Value.(*localEntry)
+	if !l.now().Before(entry.expiresAt) {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+		l.counts.Misses++
+		return nil, false
+	}
+	l.order.MoveToFront(elem)
+	l.counts.Hits++
+	return entry.data, true
+}
+
+// add stores data for the local TTL, shortened to ttl when that is positive
+// and smaller.
+func (l *localCache) add(key string, data []byte, ttl time.Duration) {
+	if ttl <= 0 || ttl > l.ttl {
+		ttl = l.ttl
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entry := &localEntry{key: key, data: data, expiresAt: l.now().Add(ttl)}
+	if elem, ok := l.entries[key]; ok {
+		elem.Value = entry
+		l.order.MoveToFront(elem)
+		return
+	}
+	l.entries[key] = l.order.PushFront(entry)
+	for l.order.Len() > l.size {
+		oldest := l.order.Back()
+		l.order.Remove(oldest)
+		delete(l.entries, oldest.Value.(*localEntry).key)
+		l.counts.Evictions++
+	}
+}
+
+func (l *localCache) remove(key string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if elem, ok := l.entries[key]; ok {
+		l.order.Remove(elem)
+		delete(l.entries, key)
+	}
+}
+
+func (l *localCache) stats() LRUStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.counts
+}
@@ -0,0 +1,103 @@
+package cache
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLocalCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	l := newLocalCache(2, time.Minute)
+	l.add("a", []byte("1"), 0)
+	l.add("b", []byte("2"), 0)
+	if _, ok := l.get("a"); !ok {
+		t.Fatal("a missing")
+	}
+	l.add("c", []byte("3"), 0)
+	if _, ok := l.get("b"); ok {
+		t.Fatal("least recently used entry b was not evicted")
+	}
+	if _, ok := l.get("a"); !ok {
+		t.Fatal("recently used entry a was evicted")
+	}
+	if got, want := l.stats(), (LRUStats{Hits: 2, Misses: 1, Evictions: 1}); got != want {
+		t.Fatalf("stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestLocalCacheExpiresEntries(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+	l := newLocalCache(10, time.Minute)
+	l.now = func() time.Time { return now }
+	l.add("long", []byte("1"), 0)
+	l.add("short", []byte("2"), time.Second)
+
+	now = now.Add(2 * time.Second)
+	if _, ok := l.get("short"); ok {
+		t.Fatal("entry outlived its Redis ttl")
+	}
+	if _, ok := l.get("long"); !ok {
+		t.Fatal("entry expired before local ttl")
+	}
+	now = now.Add(time.Minute)
+	if _, ok := l.get("long"); ok {
+		t.Fatal("entry outlived local ttl")
+	}
+}
+
+func TestLocalFallbackServesWarmEntriesWhenRedisIsDown(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { client.Close() })
+	c := &Cache{client: client, local: newLocalCache(10, time.Minute)}
+	ctx := t.Context()
+	c.local.add("user:1", []byte(`{"name":"ada"}`), 0)
+
+	// A failed write leaves the local copy as it was.
+	if err := c.SetJSON(ctx, "user:1", map[string]string{"name": "grace"}, time.Hour); err == nil {
+		t.Fatal("SetJSON succeeded against a stopped server")
+	}
+	if err := c.MSet(ctx, map[string]any{"user:2": "lin"}, time.Hour); err == nil {
+		t.Fatal("MSet succeeded against a stopped server")
+	}
+	var user map[string]string
+	if err := c.GetJSON(ctx, "user:1", &user); err != nil {
+		t.Fatalf("GetJSON of warm entry: %v", err)
+	}
+	if user["name"] != "ada" {
+		t.Fatalf("user = %v", user)
+	}
+
+	err = c.GetJSON(ctx, "user:2", &user)
+	if err == nil || IsNil(err) {
+		t.Fatalf("GetJSON of cold entry error = %v, want the Redis error, not a miss", err)
+	}
+	if got := c.LocalStats(); got.Hits != 1 || got.Misses != 1 {
+		t.Fatalf("LocalStats = %+v", got)
+	}
+}
+
+func TestOpenWithLocalFallbackValidatesSize(t *testing.T) {
+	t.Parallel()
+
+	if _, err := OpenWithLocalFallback(t.Context(), DefaultConfig(), 0, time.Minute); err == nil {
+		t.Fatal("zero size accepted")
+	}
+	if _, err := OpenWithLocalFallback(t.Context(), DefaultConfig(), 10, 0); err == nil {
+		t.Fatal("zero ttl accepted")
+	}
+}
@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrLockNotHeld is returned when a lock has expired or is owned by another
+// holder.
+var ErrLockNotHeld = errors.New("lock not held")
+
+// lockRetryInterval is how often Lock retries while another holder owns the key.
+const lockRetryInterval = 25 * time.Millisecond
+
+var (
+	unlockScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+	extendScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0`)
+)
+
+// Lock is a distributed lock held in Redis under a random ownership token.
+type Lock struct {
+	client redis.UniversalClient
+	key    string
+	token  string
+
+	mu       sync.Mutex
+	timer    *time.Timer
+	deadline time.Time
+	done     chan struct{}
+	released bool
+}
+
+// Lock acquires key with SET NX and a TTL, retrying until the lock is free or
+// ctx ends. The lock expires after ttl unless extended with Extend.
+//
+// Example:
+//
+//	lock, err := c.Lock(ctx, "jobs:nightly", 30*time.Second)
+//	if err != nil {
+//	    return err
+//	}
+//	defer lock.Unlock(context.WithoutCancel(ctx))
+func (c *Cache) Lock(ctx context.Context, key string, ttl time.Duration) (*Lock, error) {
+	if ttl < time.Millisecond {
+		return nil, fmt.Errorf("lock %q: ttl must be at least one millisecond", key)
+	}
+	token, err := lockToken()
+	if err != nil {
+		return nil, fmt.Errorf("lock %q: %w", key, err)
+	}
+	ticker := time.NewTicker(lockRetryInterval)
+	defer ticker.Stop()
+	for {
+		// Start the local TTL before the request, so Done never fires later
+		// than Redis expires the key.
+		deadline := time.Now().Add(ttl)
+		acquired, err := c.client.SetNX(ctx, c.Key(key), token, ttl).Result()
+		if err != nil {
+			return nil, fmt.Errorf("lock %q: %w", key, err)
+		}
+		if acquired {
+			lock := &Lock{client: c.client, key: c.Key(key), token: token, deadline: deadline, done: make(chan struct{})}
+			lock.timer = time.AfterFunc(time.Until(deadline), lock.expire)
+			return lock, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("lock %q: %w", key, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// Done is closed when the lock is released or its TTL elapses locally.
+func (l *Lock) Done() <-chan struct{} {
+	return l.done
+}
+
+// Extend resets the lock TTL if this holder still owns it. A lock whose TTL
+// elapsed locally, even while Extend was running, is not held.
+func (l *Lock) Extend(ctx context.Context, ttl time.Duration) error {
+	if ttl < time.Millisecond {
+		return fmt.Errorf("extend lock: ttl must be at least one millisecond")
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockNotHeld
+	}
+	if !time.Now().Before(l.deadline) {
+		l.releaseLocked()
+		return ErrLockNotHeld
+	}
+	deadline := time.Now().Add(ttl)
+	extended, err := extendScript.Run(ctx, l.client, []string{l.key}, l.token, ttl.Milliseconds()).Int64()
+	if err != nil {
+		return fmt.Errorf("extend lock: %w", err)
+	}
+	if extended == 0 {
+		l.releaseLocked()
+		return ErrLockNotHeld
+	}
+	if !l.timer.Reset(time.Until(deadline)) {
+		// The timer fired during the request, so Done may already be
+		// observed as expired. Give the extended key back instead of
+		// holding it without an owner.
+		l.releaseLocked()
+		_ = unlockScript.Run(context.WithoutCancel(ctx), l.client, []string{l.key}, l.token).Err()
+		return ErrLockNotHeld
+	}
+	l.deadline = deadline
+	return nil
+}
+
+// Unlock releases the lock if this holder still owns it. It returns
+// ErrLockNotHeld when the lock already expired or changed hands.
+func (l *Lock) Unlock(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.released {
+		return ErrLockNotHeld
+	}
+	deleted, err := unlockScript.Run(ctx, l.client, []string{l.key}, l.token).Int64()
+	if err != nil {
+		return fmt.Errorf("unlock: %w", err)
+	}
+	l.releaseLocked()
+	if deleted == 0 {
+		return ErrLockNotHeld
+	}
+	return nil
+}
+
+// expire runs when the timer fires. It checks the deadline again, so it
+// never ends a lock whose TTL was extended.
+func (l *Lock) expire() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.released && !time.Now().Before(l.deadline) {
+		l.releaseLocked()
+	}
+}
+
+func (l *Lock) releaseLocked() {
+	l.released = true
+	l.timer.Stop()
+	close(l.done)
+}
+
+func lockToken() (string, error) {
+	var token [16]byte
+	if _, err := rand.Read(token[:]); err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+	return hex.EncodeToString(token[:]), nil
+}
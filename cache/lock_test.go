@@ -0,0 +1,129 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLockRejectsShortTTL(t *testing.T) {
+	t.Parallel()
+
+	c := &Cache{}
+	if _, err := c.Lock(context.Background(), "job", 0); err == nil {
+		t.Fatal("Lock with zero ttl succeeded")
+	}
+}
+
+func TestLockMutualExclusion(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	var holders atomic.Int32
+	var wg sync.WaitGroup
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 3 {
+				lock, err := c.Lock(ctx, "critical", 5*time.Second)
+				if err != nil {
+					t.Errorf("Lock: %v", err)
+					return
+				}
+				if current := holders.Add(1); current != 1 {
+					t.Errorf("%d concurrent holders, want 1", current)
+				}
+				time.Sleep(20 * time.Millisecond)
+				holders.Add(-1)
+				if err := lock.Unlock(ctx); err != nil {
+					t.Errorf("Unlock: %v", err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestLockReleaseAndReacquire(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	first, err := c.Lock(ctx, "job", 5*time.Second)
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	if _, err := c.Lock(waitCtx, "job", 5*time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("contended Lock error = %v, want deadline exceeded", err)
+	}
+	if err := first.Extend(ctx, 10*time.Second); err != nil {
+		t.Fatalf("Extend: %v", err)
+	}
+	if err := first.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+	select {
+	case <-first.Done():
+	default:
+		t.Fatal("Done not closed after Unlock")
+	}
+	if err := first.Unlock(ctx); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("second Unlock error = %v, want ErrLockNotHeld", err)
+	}
+
+	second, err := c.Lock(ctx, "job", 5*time.Second)
+	if err != nil {
+		t.Fatalf("reacquire: %v", err)
+	}
+	if err := second.Unlock(ctx); err != nil {
+		t.Fatalf("Unlock: %v", err)
+	}
+}
+
+func TestLockExpires(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	lock, err := c.Lock(ctx, "short", 50*time.Millisecond)
+	if err != nil {
+		t.Fatalf("Lock: %v", err)
+	}
+	select {
+	case <-lock.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after ttl")
+	}
+	if err := lock.Extend(ctx, time.Second); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("Extend after expiry error = %v, want ErrLockNotHeld", err)
+	}
+}
+
+func TestLockExpireKeepsExtendedLock(t *testing.T) {
+	t.Parallel()
+
+	lock := &Lock{deadline: time.Now().Add(time.Hour), done: make(chan struct{})}
+	lock.timer = time.AfterFunc(time.Hour, func() {})
+	lock.expire()
+	select {
+	case <-lock.Done():
+		t.Fatal("stale expiry released an extended lock")
+	default:
+	}
+
+	lock.deadline = time.Now()
+	lock.expire()
+	select {
+	case <-lock.Done():
+	default:
+		t.Fatal("Done not closed after the deadline")
+	}
+	if err := lock.Extend(t.Context(), time.Second); !errors.Is(err, ErrLockNotHeld) {
+		t.Fatalf("Extend after expiry error = %v, want ErrLockNotHeld", err)
+	}
+}
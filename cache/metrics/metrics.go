@@ -0,0 +1,117 @@
+// Package metrics records Redis command counts and latency for Prometheus
+// through a go-redis hook. It is a separate module so that only applications
+// that export metrics depend on the Prometheus client.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/redis/go-redis/v9"
+)
+
+// Operation results recorded in cache_operations_total.
+const (
+	resultHit   = "hit"
+	resultMiss  = "miss"
+	resultOK    = "ok"
+	resultError = "error"
+)
+
+// readCommands report hit or miss instead of ok.
+var readCommands = map[string]bool{
+	"get": true, "getex": true, "getdel": true, "mget": true, "hget": true, "hmget": true,
+}
+
+// Hook is a redis.Hook that records every command sent through the client it
+// is added to:
+//
+//   - cache_operations_total{operation,result}: result is hit or miss for
+//     reads, ok for other successes, and error otherwise.
+//   - cache_operation_duration_seconds{operation}: command latency; a
+//     pipeline is observed once as operation "pipeline".
+type Hook struct {
+	operations *prometheus.CounterVec
+	duration   *prometheus.HistogramVec
+}
+
+// NewHook registers the cache collectors with registry and returns the hook
+// that records them. Add it before the client is shared between goroutines.
+// Registering twice in one registry returns an error; wrap the registry with
+// prometheus.WrapRegistererWith for a second client.
+//
+// Example:
+//
+//	hook, err := metrics.NewHook(prometheus.DefaultRegisterer)
+//	if err != nil {
+//	    return err
+//	}
+//	c.Client().AddHook(hook)
+func NewHook(registry prometheus.Registerer) (*Hook, error) {
+	if registry == nil {
+		return nil, errors.New("register cache metrics: nil registry")
+	}
+	h := &Hook{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "cache_operations_total",
+			Help: "Redis commands sent by the cache, by operation and result.",
+		}, []string{"operation", "result"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "cache_operation_duration_seconds",
+			Help:    "Redis command latency in seconds, by operation.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 12),
+		}, []string{"operation"}),
+	}
+	for _, collector := range []prometheus.Collector{h.operations, h.duration} {
+		if err := registry.Register(collector); err != nil {
+			return nil, fmt.Errorf("register cache metrics: %w", err)
+		}
+	}
+	return h, nil
+}
+
+// DialHook implements redis.Hook.
+func (h *Hook) DialHook(next redis.DialHook) redis.DialHook {
+	return next
+}
+
+// ProcessHook implements redis.Hook.
+func (h *Hook) ProcessHook(next redis.ProcessHook) redis.ProcessHook {
+	return func(ctx context.Context, cmd redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmd)
+		h.duration.WithLabelValues(cmd.Name()).Observe(time.Since(start).Seconds())
+		// The client sets cmd's error only after the hook chain returns.
+		h.record(cmd.Name(), err)
+		return err
+	}
+}
+
+// ProcessPipelineHook implements redis.Hook.
+func (h *Hook) ProcessPipelineHook(next redis.ProcessPipelineHook) redis.ProcessPipelineHook {
+	return func(ctx context.Context, cmds []redis.Cmder) error {
+		start := time.Now()
+		err := next(ctx, cmds)
+		h.duration.WithLabelValues("pipeline").Observe(time.Since(start).Seconds())
+		for _, cmd := range cmds {
+			h.record(cmd.Name(), cmd.Err())
+		}
+		return err
+	}
+}
+
+func (h *Hook) record(name string, err error) {
+	result := resultOK
+	switch {
+	case errors.Is(err, redis.Nil):
+		result = resultMiss
+	case err != nil:
+		result = resultError
+	case readCommands[name]:
+		result = resultHit
+	}
+	h.operations.WithLabelValues(name, result).Inc()
+}
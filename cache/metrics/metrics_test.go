@@ -0,0 +1,128 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMetricsHookLabelsResults(t *testing.T) {
+	t.Parallel()
+
+	registry := prometheus.NewPedanticRegistry()
+	hook, err := NewHook(registry)
+	if err != nil {
+		t.Fatalf("NewHook: %v", err)
+	}
+	ctx := context.Background()
+	process := func(cmd redis.Cmder, err error) {
+		_ = hook.ProcessHook(func(context.Context, redis.Cmder) error {
+			cmd.SetErr(err)
+			return err
+		})(ctx, cmd)
+	}
+	process(redis.NewStringCmd(ctx, "get", "hit"), nil)
+	process(redis.NewStringCmd(ctx, "get", "miss"), redis.Nil)
+	process(redis.NewStatusCmd(ctx, "set", "k", "v"), nil)
+	process(redis.NewIntCmd(ctx, "del", "k"), nil)
+	process(redis.NewIntCmd(ctx, "del", "k"), errors.New("connection reset"))
+
+	for _, tc := range []struct {
+		operation, result string
+		want              float64
+	}{
+		{"get", resultHit, 1}, {"get", resultMiss, 1}, {"set", resultOK, 1},
+		{"del", resultOK, 1}, {"del", resultError, 1}, {"set", resultHit, 0},
+	} {
+		if got := testutil.ToFloat64(hook.operations.WithLabelValues(tc.operation, tc.result)); got != tc.want {
+			t.Errorf("cache_operations_total{%s,%s} = %v, want %v", tc.operation, tc.result, got, tc.want)
+		}
+	}
+	if got := testutil.CollectAndCount(hook.duration); got != 3 {
+		t.Errorf("duration series = %d, want 3 (get, set, del)", got)
+	}
+	if _, err := registry.Gather(); err != nil {
+		t.Errorf("pedantic Gather: %v", err)
+	}
+	if _, err := NewHook(registry); err == nil {
+		t.Error("registering cache metrics twice succeeded")
+	}
+}
+
+func TestMetricsHookRecordsPipelines(t *testing.T) {
+	t.Parallel()
+
+	hook, err := NewHook(prometheus.NewPedanticRegistry())
+	if err != nil {
+		t.Fatalf("NewHook: %v", err)
+	}
+	ctx := context.Background()
+	cmds := []redis.Cmder{redis.NewStatusCmd(ctx, "set", "a", "1"), redis.NewStringCmd(ctx, "get", "b")}
+	_ = hook.ProcessPipelineHook(func(context.Context, []redis.Cmder) error {
+		cmds[1].SetErr(redis.Nil)
+		return nil
+	})(ctx, cmds)
+
+	want := `
+# HELP cache_operations_total Redis commands sent by the cache, by operation and result.
+# TYPE cache_operations_total counter
+cache_operations_total{operation="get",result="miss"} 1
+cache_operations_total{operation="set",result="ok"} 1
+`
+	if err := testutil.CollectAndCompare(hook.operations, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+	if got := testutil.CollectAndCount(hook.duration, "cache_operation_duration_seconds"); got != 1 {
+		t.Errorf("duration series = %d, want 1 (pipeline)", got)
+	}
+}
+
+func TestHookAgainstRedis(t *testing.T) {
+	url := os.Getenv("GOKART_TEST_REDIS_URL")
+	if url == "" {
+		t.Skip("set GOKART_TEST_REDIS_URL to run Redis integration tests")
+	}
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		t.Fatalf("ParseURL: %v", err)
+	}
+	client := redis.NewClient(opts)
+	t.Cleanup(func() { client.Close() })
+	registry := prometheus.NewPedanticRegistry()
+	hook, err := NewHook(registry)
+	if err != nil {
+		t.Fatalf("NewHook: %v", err)
+	}
+	client.AddHook(hook)
+
+	ctx := t.Context()
+	key := fmt.Sprintf("gokart-test:%s:%d", t.Name(), time.Now().UnixNano())
+	if err := client.Set(ctx, key, "ada", time.Minute).Err(); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := client.Get(ctx, key).Err(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := client.Get(ctx, key+":missing").Err(); !errors.Is(err, redis.Nil) {
+		t.Fatalf("Get(missing) = %v, want redis.Nil", err)
+	}
+	if err := client.Del(ctx, key).Err(); err != nil {
+		t.Fatalf("Del: %v", err)
+	}
+
+	count, err := testutil.GatherAndCount(registry, "cache_operations_total")
+	if err != nil {
+		t.Fatalf("GatherAndCount: %v", err)
+	}
+	if count < 4 {
+		t.Fatalf("cache_operations_total has %d series, want set/get hit/get miss/del", count)
+	}
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"context"
+	"encoding"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// MSet stores every key/value pair with the same TTL in one pipelined round
+// trip. Values are formatted by go-redis; use SetJSON for structured values.
+// With a local fallback, each value is written through to the local copy
+// like SetJSON once Redis accepts the batch, so GetJSON and MGet do not serve
+// the value it replaced.
+func (c *Cache) MSet(ctx context.Context, kvs map[string]any, ttl time.Duration) error {
+	if len(kvs) == 0 {
+		return nil
+	}
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for key, value := range kvs {
+			pipe.Set(ctx, c.Key(key), value, ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if c.local != nil {
+		for key, value := range kvs {
+			if data, ok := redisArg(value); ok {
+				c.local.add(key, data, ttl)
+			} else {
+				c.local.remove(key)
+			}
+		}
+	}
+	return nil
+}
+
+// MGet fetches keys in one pipelined round trip. Missing keys are omitted
+// from the result. Each key is read individually, so MGet also works when
+// keys hash to different cluster slots. With a local fallback, keys with a
+// local copy are served from it, and Redis is asked only for the rest.
+func (c *Cache) MGet(ctx context.Context, keys []string) (map[string]string, error) {
+	values := make(map[string]string, len(keys))
+	remote := keys
+	if c.local != nil {
+		remote = make([]string, 0, len(keys))
+		for _, key := range keys {
+			if data, ok := c.local.get(key); ok {
+				values[key] = string(data)
+			} else {
+				remote = append(remote, key)
+			}
+		}
+	}
+	if len(remote) == 0 {
+		return values, nil
+	}
+	cmds := make(map[string]*redis.StringCmd, len(remote))
+	_, err := c.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, key := range remote {
+			cmds[key] = pipe.Get(ctx, c.Key(key))
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	for key, cmd := range cmds {
+		value, err := cmd.Result()
+		if errors.Is(err, redis.Nil) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("get %q: %w", key, err)
+		}
+		values[key] = value
+		if c.local != nil {
+			c.local.add(key, []byte(value), 0)
+		}
+	}
+	return values, nil
+}
+
+// redisArg formats value the way go-redis writes a command argument, for
+// the types whose encoding is fixed. Other types report false.
+func redisArg(value any) ([]byte, bool) {
+	switch v := value.(type) {
+	case nil:
+		return []byte{}, true
+	case string:
+		return []byte(v), true
+	case []byte:
+		return append([]byte(nil), v...), true
+	case int:
+		return strconv.AppendInt(nil, int64(v), 10), true
+	case int8:
+		return strconv.AppendInt(nil, int64(v), 10), true
+	case int16:
+		return strconv.AppendInt(nil, int64(v), 10), true
+	case int32:
+		return strconv.AppendInt(nil, int64(v), 10), true
+	case int64:
+		return strconv.AppendInt(nil, v, 10), true
+	case uint:
+		return strconv.AppendUint(nil, uint64(v), 10), true
+	case uint8:
+		return strconv.AppendUint(nil, uint64(v), 10), true
+	case uint16:
+		return strconv.AppendUint(nil, uint64(v), 10), true
+	case uint32:
+		return strconv.AppendUint(nil, uint64(v), 10), true
+	case uint64:
+		return strconv.AppendUint(nil, v, 10), true
+	case float32:
+		return strconv.AppendFloat(nil, float64(v), 'f', -1, 32), true
+	case float64:
+		return strconv.AppendFloat(nil, v, 'f', -1, 64), true
+	case bool:
+		if v {
+			return []byte("1"), true
+		}
+		return []byte("0"), true
+	case time.Time:
+		return v.AppendFormat(nil, time.RFC3339Nano), true
+	case time.Duration:
+		return strconv.AppendInt(nil, v.Nanoseconds(), 10), true
+	case encoding.BinaryMarshaler:
+		data, err := v.MarshalBinary()
+		return data, err == nil
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,97 @@
+package cache
+
+import (
+	"errors"
+	"maps"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestMSetMGet(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	if err := c.MSet(ctx, map[string]any{"a": "1", "b": 2, "c": []byte("three")}, time.Minute); err != nil {
+		t.Fatalf("MSet: %v", err)
+	}
+	got, err := c.MGet(ctx, []string{"a", "b", "c", "missing"})
+	if err != nil {
+		t.Fatalf("MGet: %v", err)
+	}
+	want := map[string]string{"a": "1", "b": "2", "c": "three"}
+	if !maps.Equal(got, want) {
+		t.Fatalf("MGet = %v, want %v", got, want)
+	}
+	if ttl := c.Client().TTL(ctx, c.Key("a")).Val(); ttl <= 0 || ttl > time.Minute {
+		t.Fatalf("TTL = %v, want within one minute", ttl)
+	}
+}
+
+func TestTxPipelineAbortsOnCallbackError(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	abort := errors.New("abort")
+	_, err := c.Client().TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.Key("first"), "1", time.Minute)
+		pipe.Set(ctx, c.Key("second"), "2", time.Minute)
+		return abort
+	})
+	if !errors.Is(err, abort) {
+		t.Fatalf("TxPipelined error = %v, want %v", err, abort)
+	}
+	got, err := c.MGet(ctx, []string{"first", "second"})
+	if err != nil {
+		t.Fatalf("MGet: %v", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("aborted transaction wrote %v", got)
+	}
+
+	_, err = c.Client().TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, c.Key("first"), "1", time.Minute)
+		pipe.Set(ctx, c.Key("second"), "2", time.Minute)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("TxPipelined: %v", err)
+	}
+	if got, err := c.MGet(ctx, []string{"first", "second"}); err != nil || len(got) != 2 {
+		t.Fatalf("MGet = %v, %v", got, err)
+	}
+}
+
+func TestMSetMGetUseLocalFallback(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1, DialTimeout: 100 * time.Millisecond})
+	t.Cleanup(func() { client.Close() })
+	c := &Cache{client: client, local: newLocalCache(10, time.Minute)}
+	ctx := t.Context()
+
+	c.local.add("a", []byte("stale"), 0)
+	c.local.add("b", []byte("stale"), 0)
+	if err := c.MSet(ctx, map[string]any{"a": "1", "b": struct{}{}, "n": 42}, time.Hour); err == nil {
+		t.Fatal("MSet succeeded against a stopped server")
+	}
+	got, err := c.MGet(ctx, []string{"a", "n"})
+	if err != nil {
+		t.Fatalf("MGet of warm entries: %v", err)
+	}
+	if want := map[string]string{"a": "1", "n": "42"}; !maps.Equal(got, want) {
+		t.Fatalf("MGet = %v, want %v", got, want)
+	}
+	if _, err := c.MGet(ctx, []string{"b"}); err == nil || IsNil(err) {
+		t.Fatalf("MGet of invalidated entry error = %v, want the Redis error", err)
+	}
+}
@@ -0,0 +1,135 @@
+package cache
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestNewClientUsesFailoverClientForSentinel(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.SentinelAddrs = []string{"sentinel-1:26379", "sentinel-2:26379"}
+	cfg.SentinelMasterName = "mymaster"
+	cfg.Password = "secret"
+	cfg.DB = 3
+	client, err := newClient(cfg)
+	if err != nil {
+		t.Fatalf("newClient: %v", err)
+	}
+	defer client.Close()
+
+	failover, ok := client.(*redis.Client)
+	if !ok {
+		t.Fatalf("newClient returned %T, want *redis.Client", client)
+	}
+	opts := failover.Options()
+	if opts.Addr != "FailoverClient" {
+		t.Errorf("Addr = %q, want the failover client marker", opts.Addr)
+	}
+	if opts.Password != "secret" || opts.DB != 3 || opts.PoolSize != cfg.PoolSize {
+		t.Errorf("failover options = password %q, DB %d, pool %d", opts.Password, opts.DB, opts.PoolSize)
+	}
+}
+
+func TestNewClientSelectsClusterClient(t *testing.T) {
+	t.Parallel()
+
+	cfg := DefaultConfig()
+	cfg.ClusterAddrs = []string{"node-1:7000", "node-2:7000"}
+	client, err := newClient(cfg)
+	if err != nil {
+		t.Fatalf("newClient: %v", err)
+	}
+	defer client.Close()
+	cluster, ok := client.(*redis.ClusterClient)
+	if !ok {
+		t.Fatalf("newClient with ClusterAddrs returned %T, want *redis.ClusterClient", client)
+	}
+	if got := cluster.Options().Addrs; len(got) != 2 || got[0] != "node-1:7000" {
+		t.Errorf("cluster Addrs = %v", got)
+	}
+
+	standalone, err := newClient(DefaultConfig())
+	if err != nil {
+		t.Fatalf("newClient: %v", err)
+	}
+	defer standalone.Close()
+	if _, ok := standalone.(*redis.Client); !ok {
+		t.Fatalf("newClient without ClusterAddrs returned %T, want *redis.Client", standalone)
+	}
+}
+
+func TestOpenWithConfigRejectsInvalidTopology(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want string
+	}{
+		{
+			name: "missing master name",
+			cfg:  Config{SentinelAddrs: []string{"localhost:26379"}},
+			want: "SentinelMasterName",
+		},
+		{
+			name: "URL and sentinels",
+			cfg:  Config{URL: "redis://localhost:6379", SentinelAddrs: []string{"localhost:26379"}, SentinelMasterName: "mymaster"},
+			want: "mutually exclusive",
+		},
+		{
+			name: "sentinels and cluster",
+			cfg:  Config{SentinelAddrs: []string{"localhost:26379"}, SentinelMasterName: "mymaster", ClusterAddrs: []string{"localhost:7000"}},
+			want: "mutually exclusive",
+		},
+		{
+			name: "cluster with DB",
+			cfg:  Config{ClusterAddrs: []string{"localhost:7000"}, DB: 1},
+			want: "DB 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := OpenWithConfig(t.Context(), tt.cfg)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("OpenWithConfig error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenWithConfigThroughSentinel(t *testing.T) {
+	addrs := os.Getenv("GOKART_TEST_REDIS_SENTINEL_ADDRS")
+	master := os.Getenv("GOKART_TEST_REDIS_SENTINEL_MASTER")
+	if addrs == "" || master == "" {
+		t.Skip("set GOKART_TEST_REDIS_SENTINEL_ADDRS and GOKART_TEST_REDIS_SENTINEL_MASTER to run Sentinel integration tests")
+	}
+	cfg := DefaultConfig()
+	cfg.SentinelAddrs = strings.Split(addrs, ",")
+	cfg.SentinelMasterName = master
+	cfg.KeyPrefix = "gokart-test:" + t.Name() + ":"
+	c, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig: %v", err)
+	}
+	defer c.Close()
+
+	type session struct{ User string }
+	if err := c.SetJSON(t.Context(), "session", session{User: "ada"}, time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	var got session
+	if err := c.GetJSON(t.Context(), "session", &got); err != nil || got.User != "ada" {
+		t.Fatalf("GetJSON = %+v, %v", got, err)
+	}
+	if err := c.Client().Del(t.Context(), c.Key("session")).Err(); err != nil {
+		t.Errorf("cleanup: %v", err)
+	}
+}
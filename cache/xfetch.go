@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// xfetchEntry is the stored form of a FetchJSON value: the value plus how long
+// it took to compute and when its logical TTL ends.
+type xfetchEntry struct {
+	Value  json.RawMessage `json:"value"`
+	Delta  int64           `json:"delta"`
+	Expiry int64           `json:"expiry"`
+}
+
+// FetchJSON is RememberJSON with probabilistic early expiration (XFetch,
+// Vattani et al. 2015). Each read near the end of ttl may recompute early,
+// with a probability that grows with how long fn took last time and with
+// beta, so a hot key is usually refreshed by one caller before it expires
+// instead of by all of them after. Concurrent recomputes in this process are
+// collapsed into one. beta of 1 is the usual choice; values <= 0 use 1.
+//
+// Example:
+//
+//	report, err := cache.FetchJSON(ctx, c, "report:daily", time.Hour, 1, func() (Report, error) {
+//	    return buildReport(ctx)
+//	})
+func FetchJSON[T any](ctx context.Context, c *Cache, key string, ttl time.Duration, beta float64, fn func() (T, error)) (T, error) {
+	var zero T
+	if fn == nil {
+		return zero, fmt.Errorf("fetch %q: nil callback", key)
+	}
+	if ttl <= 0 {
+		return zero, fmt.Errorf("fetch %q: ttl must be positive", key)
+	}
+	if beta <= 0 {
+		beta = 1
+	}
+
+	data, err := c.get(ctx, key)
+	switch {
+	case err == nil:
+		var entry xfetchEntry
+		if json.Unmarshal(data, &entry) == nil && !shouldRecompute(c.xfetchNow(), entry, beta, c.xfetchRand()) {
+			var value T
+			if err := json.Unmarshal(entry.Value, &value); err == nil {
+				return value, nil
+			}
+		}
+	case !IsNil(err):
+		return zero, err
+	}
+
+	result, err, _ := c.flight.Do(flightKey[T]("xfetch", key), func() (interface{}, error) {
+		start := c.xfetchNow()
+		value, err := fn()
+		if err != nil {
+			return nil, err
+		}
+		finished := c.xfetchNow()
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal value: %w", err)
+		}
+		data, err := json.Marshal(xfetchEntry{Value: raw, Delta: int64(finished.Sub(start)), Expiry: finished.Add(ttl).UnixNano()})
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal entry: %w", err)
+		}
+		if err := c.set(ctx, key, data, ttl); err != nil {
+			return nil, err
+		}
+		return value, nil
+	})
+	if err != nil {
+		return zero, err
+	}
+	return sharedResult[T](key, result)
+}
+
+// xfetchNow returns the FetchJSON clock, time.Now unless a test set one.
+func (c *Cache) xfetchNow() time.Time {
+	if c.now != nil {
+		return c.now()
+	}
+	return time.Now()
+}
+
+// xfetchRand returns a FetchJSON random draw in (0, 1].
+func (c *Cache) xfetchRand() float64 {
+	if c.rand != nil {
+		return c.rand()
+	}
+	return 1 - rand.Float64()
+}
+
+// shouldRecompute applies the XFetch test: recompute once
+// now - delta*beta*ln(r) reaches the expiry, for r drawn from (0, 1].
+func shouldRecompute(now time.Time, entry xfetchEntry, beta, r float64) bool {
+	early := -float64(entry.Delta) * beta * math.Log(r)
+	return float64(now.UnixNano())+early >= float64(entry.Expiry)
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestShouldRecomputeWindow(t *testing.T) {
+	t.Parallel()
+
+	expiry := time.Unix(1000, 0)
+	entry := xfetchEntry{Delta: int64(time.Second), Expiry: expiry.UnixNano()}
+	// With r = 1/e the early window is exactly delta*beta.
+	r := 1 / math.E
+
+	tests := []struct {
+		name string
+		now  time.Time
+		beta float64
+		want bool
+	}{
+		{"well before window", expiry.Add(-3 * time.Second), 1, false},
+		{"just before window", expiry.Add(-1100 * time.Millisecond), 1, false},
+		{"inside window", expiry.Add(-900 * time.Millisecond), 1, true},
+		{"after expiry", expiry.Add(time.Millisecond), 1, true},
+		{"larger beta widens window", expiry.Add(-1500 * time.Millisecond), 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := shouldRecompute(tt.now, entry, tt.beta, r); got != tt.want {
+				t.Fatalf("shouldRecompute = %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if shouldRecompute(expiry.Add(-time.Hour), entry, 1, 1) {
+		t.Fatal("r = 1 must never recompute before expiry")
+	}
+}
+
+func TestFetchJSONRecomputesEarly(t *testing.T) {
+	c := openTestCache(t)
+	ctx := t.Context()
+
+	now := time.Unix(1000, 0)
+	c.now = func() time.Time { return now }
+	c.rand = func() float64 { return 1 / math.E }
+
+	calls := 0
+	fetch := func() (int, error) {
+		return FetchJSON(ctx, c, "hot", time.Minute, 1, func() (int, error) {
+			calls++
+			now = now.Add(2 * time.Second) // the computation takes two seconds
+			return calls, nil
+		})
+	}
+
+	if got, err := fetch(); err != nil || got != 1 {
+		t.Fatalf("first fetch = %d, %v", got, err)
+	}
+	// Expiry is one minute after the first computation finished; the early
+	// window is the two-second compute time.
+	now = now.Add(time.Minute - 3*time.Second)
+	if got, err := fetch(); err != nil || got != 1 {
+		t.Fatalf("fetch before window = %d, %v; want cached 1", got, err)
+	}
+	now = now.Add(2 * time.Second)
+	if got, err := fetch(); err != nil || got != 2 {
+		t.Fatalf("fetch inside window = %d, %v; want recomputed 2", got, err)
+	}
+}
+
+func TestFetchJSONRejectsInvalidArguments(t *testing.T) {
+	t.Parallel()
+
+	c := &Cache{}
+	if _, err := FetchJSON[int](t.Context(), c, "k", time.Minute, 1, nil); err == nil {
+		t.Fatal("nil callback accepted")
+	}
+	if _, err := FetchJSON(t.Context(), c, "k", 0, 1, func() (int, error) { return 0, nil }); err == nil {
+		t.Fatal("zero ttl accepted")
+	}
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BoxStyle controls how a box is drawn. Padding is the number of spaces
+// between the side borders and the content.
+type BoxStyle struct {
+	BorderStyle  lipgloss.Border
+	TitleColor   lipgloss.Color
+	ContentColor lipgloss.Color
+	BorderColor  lipgloss.Color
+	Padding      int
+}
+
+// Predefined box styles using the Success, Warning, Error, and Info colors.
+var (
+	BoxStyleInfo    = BoxStyle{BorderStyle: lipgloss.RoundedBorder(), TitleColor: "12", BorderColor: "12", Padding: 1}
+	BoxStyleWarning = BoxStyle{BorderStyle: lipgloss.RoundedBorder(), TitleColor: "11", BorderColor: "11", Padding: 1}
+	BoxStyleError   = BoxStyle{BorderStyle: lipgloss.RoundedBorder(), TitleColor: "9", BorderColor: "9", Padding: 1}
+	BoxStyleSuccess = BoxStyle{BorderStyle: lipgloss.RoundedBorder(), TitleColor: "10", BorderColor: "10", Padding: 1}
+)
+
+// Box builds a bordered panel with an optional title in its top border.
+type Box struct {
+	title   string
+	content string
+	style   BoxStyle
+	writer  io.Writer
+}
+
+// BoxOption configures a Box created by NewBox.
+type BoxOption func(*Box)
+
+// WithBoxStyle sets the box style (default: BoxStyleInfo).
+func WithBoxStyle(style BoxStyle) BoxOption {
+	return func(b *Box) {
+		b.style = style
+	}
+}
+
+// NewBox creates a box with a title.
+//
+// Example:
+//
+//	cli.NewBox("Deployed", cli.WithBoxStyle(cli.BoxStyleSuccess)).
+//	    Content("URL: https://example.com\nVersion: v1.4.0").
+//	    Print()
+func NewBox(title string, opts ...BoxOption) *Box {
+	b := &Box{title: title, style: BoxStyleInfo, writer: os.Stdout}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Content sets the box body. Multi-line content keeps its line breaks.
+func (b *Box) Content(content string) *Box {
+	b.content = content
+	return b
+}
+
+// Style sets the box style.
+func (b *Box) Style(style BoxStyle) *Box {
+	b.style = style
+	return b
+}
+
+// SetWriter sets the output writer.
+func (b *Box) SetWriter(w io.Writer) *Box {
+	b.writer = w
+	return b
+}
+
+// Print renders the box to the configured writer.
+func (b *Box) Print() {
+	fmt.Fprintln(b.writer, b.String())
+}
+
+// String returns the rendered box.
+func (b *Box) String() string {
+	return RenderBox(b.title, b.content, b.style)
+}
+
+// RenderBox renders content in a bordered box with title embedded in the
+// top border.
+//
+// Example:
+//
+//	fmt.Println(cli.RenderBox("Warning", "Disk usage is at 91%", cli.BoxStyleWarning))
+func RenderBox(title, content string, style BoxStyle) string {
+	border := style.BorderStyle
+	if border == (lipgloss.Border{}) {
+		border = lipgloss.NormalBorder()
+	}
+	pad := max(style.Padding, 0)
+	borderStyle := lipgloss.NewStyle().Foreground(style.BorderColor)
+	titleStyle := lipgloss.NewStyle().Foreground(style.TitleColor).Bold(true)
+	contentStyle := lipgloss.NewStyle().Foreground(style.ContentColor)
+
+	lines := strings.Split(content, "\n")
+	inner := 0
+	for _, line := range lines {
+		inner = max(inner, lipgloss.Width(line))
+	}
+	width := inner + 2*pad
+	titleWidth := lipgloss.Width(title)
+	if title != "" {
+		width = max(width, titleWidth+4)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(borderStyle.Render(border.TopLeft))
+	if title != "" {
+		sb.WriteString(borderStyle.Render(border.Top + " "))
+		sb.WriteString(titleStyle.Render(title))
+		sb.WriteString(borderStyle.Render(" " + strings.Repeat(border.Top, width-titleWidth-3)))
+	} else {
+		sb.WriteString(borderStyle.Render(strings.Repeat(border.Top, width)))
+	}
+	sb.WriteString(borderStyle.Render(border.TopRight))
+	sb.WriteByte('\n')
+
+	for _, line := range lines {
+		fill := width - 2*pad - lipgloss.Width(line)
+		sb.WriteString(borderStyle.Render(border.Left))
+		sb.WriteString(strings.Repeat(" ", pad))
+		sb.WriteString(contentStyle.Render(line))
+		sb.WriteString(strings.Repeat(" ", fill+pad))
+		sb.WriteString(borderStyle.Render(border.Right))
+		sb.WriteByte('\n')
+	}
+
+	sb.WriteString(borderStyle.Render(border.BottomLeft + strings.Repeat(border.Bottom, width) + border.BottomRight))
+	return sb.String()
+}
+
+// Panel renders titled sections, sorted by title, in one info box.
+//
+// Example:
+//
+//	fmt.Println(cli.Panel(map[string]string{
+//	    "Server":   "listening on :8080",
+//	    "Database": "postgres://localhost/app",
+//	}))
+func Panel(sections map[string]string) string {
+	titles := make([]string, 0, len(sections))
+	for title := range sections {
+		titles = append(titles, title)
+	}
+	sort.Strings(titles)
+
+	blocks := make([]string, 0, len(titles))
+	for _, title := range titles {
+		blocks = append(blocks, styleHeading.Render(title)+"\n"+sections[title])
+	}
+	return RenderBox("", strings.Join(blocks, "\n\n"), BoxStyleInfo)
+}
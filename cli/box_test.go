@@ -0,0 +1,62 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/dotcommander/gokart/cli"
+)
+
+func TestRenderBox(t *testing.T) {
+	t.Parallel()
+
+	style := cli.BoxStyle{BorderStyle: lipgloss.NormalBorder(), Padding: 2}
+	got := cli.RenderBox("Status", "ok\nall good", style)
+	want := strings.Join([]string{
+		"┌─ Status ───┐",
+		"│  ok        │",
+		"│  all good  │",
+		"└────────────┘",
+	}, "\n")
+	if got != want {
+		t.Errorf("RenderBox =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRenderBox_LongTitle(t *testing.T) {
+	t.Parallel()
+
+	got := cli.RenderBox("Deployment summary", "ok", cli.BoxStyle{Padding: 1})
+	lines := strings.Split(got, "\n")
+	if !strings.HasPrefix(lines[0], "┌─ Deployment summary ") {
+		t.Fatalf("title not on border: %q", lines[0])
+	}
+	for _, line := range lines[1:] {
+		if lipgloss.Width(line) != lipgloss.Width(lines[0]) {
+			t.Errorf("line %q width %d, want %d", line, lipgloss.Width(line), lipgloss.Width(lines[0]))
+		}
+	}
+}
+
+func TestNewBox(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	cli.NewBox("Done", cli.WithBoxStyle(cli.BoxStyleSuccess)).Content("3 files").SetWriter(&out).Print()
+	want := "╭─ Done ──╮\n│ 3 files │\n╰─────────╯\n"
+	if out.String() != want {
+		t.Errorf("box =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestPanel(t *testing.T) {
+	t.Parallel()
+
+	got := cli.Panel(map[string]string{"Server": ":8080", "Database": "sqlite"})
+	db, server := strings.Index(got, "Database"), strings.Index(got, "Server")
+	if db < 0 || server < 0 || db > server {
+		t.Errorf("sections missing or unsorted:\n%s", got)
+	}
+}
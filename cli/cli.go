@@ -23,6 +23,7 @@ type App struct {
 	configFile  string
 	configName  string
 	envPrefix   string
+	updateCheck func()
 }
 
 // NewApp creates a new CLI application builder.
@@ -105,12 +106,61 @@ func (a *App) WithStandardFlags() *App {
 	return a
 }
 
-// AddCommand adds a subcommand.
+// WithPromptFlags adds persistent --yes/-y and --no-interactive flags, which
+// PrompterFor honors. Commands that define their own -y or --yes must not
+// use it.
+func (a *App) WithPromptFlags() *App {
+	flags := a.root.PersistentFlags()
+	flags.BoolP("yes", "y", false, "answer yes to confirmation prompts")
+	flags.Bool("no-interactive", false, "never prompt; use default answers")
+	return a
+}
+
+// AddCommand adds a subcommand. A command assigned to a group with InGroup
+// creates that group if the app does not have it yet.
 func (a *App) AddCommand(cmd *cobra.Command) *App {
+	if cmd.GroupID != "" {
+		a.ensureGroup(cmd.GroupID)
+	}
 	a.root.AddCommand(cmd)
 	return a
 }
 
+// AddCommandGroup adds commands under a labeled section of the root help.
+// Sections are listed in the order their groups are first added.
+//
+// Example:
+//
+//	app.AddCommandGroup("data", importCmd, exportCmd)
+func (a *App) AddCommandGroup(name string, cmds ...*cobra.Command) *App {
+	a.ensureGroup(name)
+	for _, cmd := range cmds {
+		cmd.GroupID = name
+		a.root.AddCommand(cmd)
+	}
+	return a
+}
+
+// WithDefaultGroups creates the "core", "data", and "admin" help sections,
+// in that order.
+func (a *App) WithDefaultGroups() *App {
+	for _, name := range []string{"core", "data", "admin"} {
+		a.ensureGroup(name)
+	}
+	return a
+}
+
+func (a *App) ensureGroup(name string) {
+	if a.root.ContainsGroup(name) {
+		return
+	}
+	title := name
+	if title != "" {
+		title = strings.ToUpper(title[:1]) + title[1:]
+	}
+	a.root.AddGroup(&cobra.Group{ID: name, Title: title + " Commands:"})
+}
+
 // Root returns the root cobra command for advanced customization.
 func (a *App) Root() *cobra.Command {
 	return a.root
@@ -123,13 +173,22 @@ func (a *App) Viper() *viper.Viper {
 
 // Run executes the CLI application.
 func (a *App) Run() error {
-	return a.root.Execute()
+	return a.execute()
 }
 
 // RunWithArgs executes with specific arguments (useful for testing).
 func (a *App) RunWithArgs(args []string) error {
 	a.root.SetArgs(args)
-	return a.root.Execute()
+	return a.execute()
+}
+
+func (a *App) execute() error {
+	a.updateCheck = nil
+	err := a.root.Execute()
+	if err == nil && a.updateCheck != nil {
+		a.updateCheck()
+	}
+	return err
 }
 
 // initConfig loads configuration from file and environment.
@@ -155,37 +214,56 @@ func (a *App) initConfig() error {
 	return nil
 }
 
+// CommandOption configures a command built by Command, CommandWithArgs, or
+// Group.
+type CommandOption func(*cobra.Command)
+
+// InGroup lists the command under the named help section. App.AddCommand
+// creates the section when needed.
+func InGroup(name string) CommandOption {
+	return func(cmd *cobra.Command) {
+		cmd.GroupID = name
+	}
+}
+
 // Command creates a new cobra command with common setup.
 //
 // Example:
 //
 //	cmd := cli.Command("serve", "Start the server", func(cmd *cobra.Command, args []string) error {
 //	    return server.Run()
-//	})
-func Command(use, short string, run func(cmd *cobra.Command, args []string) error) *cobra.Command {
-	return &cobra.Command{
+//	}, cli.InGroup("core"))
+func Command(use, short string, run func(cmd *cobra.Command, args []string) error, opts ...CommandOption) *cobra.Command {
+	return applyCommandOptions(&cobra.Command{
 		Use:   use,
 		Short: short,
 		RunE:  run,
-	}
+	}, opts)
 }
 
 // CommandWithArgs creates a command that requires positional arguments.
-func CommandWithArgs(use, short string, nArgs int, run func(cmd *cobra.Command, args []string) error) *cobra.Command {
-	return &cobra.Command{
+func CommandWithArgs(use, short string, nArgs int, run func(cmd *cobra.Command, args []string) error, opts ...CommandOption) *cobra.Command {
+	return applyCommandOptions(&cobra.Command{
 		Use:   use,
 		Short: short,
 		Args:  cobra.ExactArgs(nArgs),
 		RunE:  run,
-	}
+	}, opts)
 }
 
 // Group creates a command group (no run function, just subcommands).
-func Group(use, short string) *cobra.Command {
-	return &cobra.Command{
+func Group(use, short string, opts ...CommandOption) *cobra.Command {
+	return applyCommandOptions(&cobra.Command{
 		Use:   use,
 		Short: short,
+	}, opts)
+}
+
+func applyCommandOptions(cmd *cobra.Command, opts []CommandOption) *cobra.Command {
+	for _, opt := range opts {
+		opt(cmd)
 	}
+	return cmd
 }
 
 // --- Output Styling ---
@@ -264,10 +342,16 @@ var styledHelpTemplate = `{{ styleTitle .Short }}
 {{ end }}
 {{ styleHeading "Usage:" }}
   {{ styleCommand .UseLine }}
-{{ if .HasAvailableSubCommands }}
-{{ styleHeading "Commands:" }}{{ range .Commands }}{{ if .IsAvailableCommand }}
+{{ if .HasAvailableSubCommands }}{{ $cmds := .Commands }}{{ if eq (len .Groups) 0 }}
+{{ styleHeading "Commands:" }}{{ range $cmds }}{{ if .IsAvailableCommand }}
   {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
-{{ end }}
+{{ else }}{{ range $group := .Groups }}
+{{ styleHeading $group.Title }}{{ range $cmds }}{{ if and (eq .GroupID $group.ID) .IsAvailableCommand }}
+  {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
+{{ end }}{{ if not .AllChildCommandsHaveGroup }}
+{{ styleHeading "Additional Commands:" }}{{ range $cmds }}{{ if and (eq .GroupID "") .IsAvailableCommand }}
+  {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
+{{ end }}{{ end }}{{ end }}
 {{ if .HasAvailableLocalFlags }}
 {{ styleHeading "Flags:" }}
 {{ .LocalFlags.FlagUsages | trimTrailingWhitespaces }}
@@ -285,10 +369,16 @@ var styledHelpTemplate = `{{ styleTitle .Short }}
 
 var styledUsageTemplate = `{{ styleHeading "Usage:" }}
   {{ styleCommand .UseLine }}
-{{ if .HasAvailableSubCommands }}
-{{ styleHeading "Commands:" }}{{ range .Commands }}{{ if .IsAvailableCommand }}
+{{ if .HasAvailableSubCommands }}{{ $cmds := .Commands }}{{ if eq (len .Groups) 0 }}
+{{ styleHeading "Commands:" }}{{ range $cmds }}{{ if .IsAvailableCommand }}
   {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
-{{ end }}
+{{ else }}{{ range $group := .Groups }}
+{{ styleHeading $group.Title }}{{ range $cmds }}{{ if and (eq .GroupID $group.ID) .IsAvailableCommand }}
+  {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
+{{ end }}{{ if not .AllChildCommandsHaveGroup }}
+{{ styleHeading "Additional Commands:" }}{{ range $cmds }}{{ if and (eq .GroupID "") .IsAvailableCommand }}
+  {{ styleCommand (rpad .Name .NamePadding) }}  {{ .Short }}{{ end }}{{ end }}
+{{ end }}{{ end }}{{ end }}
 {{ if .HasAvailableLocalFlags }}
 {{ styleHeading "Flags:" }}
 {{ .LocalFlags.FlagUsages | trimTrailingWhitespaces }}
@@ -0,0 +1,47 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+	"github.com/spf13/cobra"
+)
+
+func noop(cmd *cobra.Command, args []string) error { return nil }
+
+func TestApp_CommandGroups(t *testing.T) {
+	t.Parallel()
+
+	for _, styled := range []bool{false, true} {
+		app := cli.NewApp("groups", "0.0.0").WithDefaultGroups()
+		app.AddCommand(cli.Command("serve", "Start the server", noop, cli.InGroup("core")))
+		app.AddCommandGroup("data", cli.Command("import", "Import records", noop))
+		app.AddCommand(cli.Command("users", "Manage users", noop, cli.InGroup("admin")))
+		app.AddCommand(cli.Command("reports", "Build reports", noop, cli.InGroup("reporting")))
+		app.AddCommand(cli.Command("misc", "Ungrouped command", noop))
+		if styled {
+			cli.SetStyledHelp(app.Root())
+		}
+
+		var out bytes.Buffer
+		app.Root().SetOut(&out)
+		if err := app.RunWithArgs([]string{"--help"}); err != nil {
+			t.Fatalf("help: %v", err)
+		}
+		help := out.String()
+
+		last := -1
+		for _, section := range []string{"Core Commands:", "serve", "Data Commands:", "import", "Admin Commands:", "users", "Reporting Commands:", "reports", "Additional Commands:", "misc"} {
+			i := strings.Index(help, section)
+			if i < 0 {
+				t.Fatalf("styled=%v: help missing %q:\n%s", styled, section, help)
+			}
+			if i < last {
+				t.Errorf("styled=%v: %q out of order:\n%s", styled, section, help)
+			}
+			last = i
+		}
+	}
+}
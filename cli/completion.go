@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// AddCompletionCommand adds a hidden "completion" command with bash, zsh,
+// fish, and powershell subcommands that print the shell's completion
+// script. It replaces Cobra's default completion command so the command
+// stays out of the main help while "app completion --help" still works.
+//
+// Example:
+//
+//	app.AddCompletionCommand()
+//	// myapp completion zsh > "${fpath[1]}/_myapp"
+func (a *App) AddCompletionCommand() *App {
+	a.root.CompletionOptions.DisableDefaultCmd = true
+
+	completion := &cobra.Command{
+		Use:    "completion",
+		Short:  "Generate shell completion scripts",
+		Long:   fmt.Sprintf("Generate a completion script for %s. Load it in the current shell or save it to the shell's completion directory.", a.name),
+		Hidden: true,
+		Args:   cobra.NoArgs,
+	}
+	shells := []struct {
+		name string
+		gen  func(cmd *cobra.Command) error
+	}{
+		{"bash", func(cmd *cobra.Command) error { return cmd.Root().GenBashCompletionV2(cmd.OutOrStdout(), true) }},
+		{"zsh", func(cmd *cobra.Command) error { return cmd.Root().GenZshCompletion(cmd.OutOrStdout()) }},
+		{"fish", func(cmd *cobra.Command) error { return cmd.Root().GenFishCompletion(cmd.OutOrStdout(), true) }},
+		{"powershell", func(cmd *cobra.Command) error {
+			return cmd.Root().GenPowerShellCompletionWithDesc(cmd.OutOrStdout())
+		}},
+	}
+	for _, shell := range shells {
+		completion.AddCommand(&cobra.Command{
+			Use:                   shell.name,
+			Short:                 fmt.Sprintf("Generate the %s completion script", shell.name),
+			Args:                  cobra.NoArgs,
+			DisableFlagsInUseLine: true,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return shell.gen(cmd)
+			},
+		})
+	}
+
+	a.root.AddCommand(completion)
+	return a
+}
+
+// RegisterCompleter registers dynamic completion for a flag defined on the
+// root command, including persistent flags. Use Cobra's
+// RegisterFlagCompletionFunc on a subcommand for its local flags.
+//
+// Example:
+//
+//	err := app.RegisterCompleter("env", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+//	    return []string{"dev", "staging", "prod"}, cobra.ShellCompDirectiveNoFileComp
+//	})
+func (a *App) RegisterCompleter(flag string, fn func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)) error {
+	if err := a.root.RegisterFlagCompletionFunc(flag, fn); err != nil {
+		return fmt.Errorf("register completion for --%s: %w", flag, err)
+	}
+	return nil
+}
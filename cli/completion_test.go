@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+	"github.com/spf13/cobra"
+)
+
+func newCompletionApp() *cli.App {
+	app := cli.NewApp("compapp", "0.0.0").WithStandardFlags().WithOutputFlag().AddCompletionCommand()
+	app.AddCommand(cli.Command("serve", "Start the server", func(cmd *cobra.Command, args []string) error {
+		return nil
+	}))
+	return app
+}
+
+func runCompletionApp(t *testing.T, app *cli.App, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	app.Root().SetOut(&out)
+	app.Root().SetErr(&out)
+	if err := app.RunWithArgs(args); err != nil {
+		t.Fatalf("RunWithArgs(%v): %v", args, err)
+	}
+	return out.String()
+}
+
+func TestAddCompletionCommand_Scripts(t *testing.T) {
+	t.Parallel()
+
+	interpreters := map[string][]string{
+		"bash": {"bash", "-n"},
+		"zsh":  {"zsh", "-n"},
+		"fish": {"fish", "--no-execute"},
+	}
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			t.Parallel()
+
+			script := runCompletionApp(t, newCompletionApp(), "completion", shell)
+			if !strings.Contains(script, "compapp") {
+				t.Fatalf("%s script does not mention the app:\n%s", shell, script)
+			}
+
+			argv, ok := interpreters[shell]
+			if !ok {
+				return
+			}
+			if _, err := exec.LookPath(argv[0]); err != nil {
+				t.Skipf("%s not installed", argv[0])
+			}
+			path := filepath.Join(t.TempDir(), "completion."+shell)
+			if err := os.WriteFile(path, []byte(script), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			if out, err := exec.Command(argv[0], append(argv[1:], path)...).CombinedOutput(); err != nil {
+				t.Fatalf("%s rejected the script: %v\n%s", shell, err, out)
+			}
+		})
+	}
+}
+
+func TestAddCompletionCommand_HiddenFromHelp(t *testing.T) {
+	t.Parallel()
+
+	help := runCompletionApp(t, newCompletionApp(), "--help")
+	if strings.Contains(help, "completion") {
+		t.Errorf("root help lists completion:\n%s", help)
+	}
+	if !strings.Contains(help, "serve") {
+		t.Errorf("root help missing serve:\n%s", help)
+	}
+
+	completionHelp := runCompletionApp(t, newCompletionApp(), "completion", "--help")
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		if !strings.Contains(completionHelp, shell) {
+			t.Errorf("completion help missing %s:\n%s", shell, completionHelp)
+		}
+	}
+}
+
+func TestRegisterCompleter(t *testing.T) {
+	t.Parallel()
+
+	app := newCompletionApp()
+	err := app.RegisterCompleter("output", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json", "yaml"}, cobra.ShellCompDirectiveNoFileComp
+	})
+	if err != nil {
+		t.Fatalf("RegisterCompleter: %v", err)
+	}
+
+	out := runCompletionApp(t, app, cobra.ShellCompRequestCmd, "serve", "--output", "")
+	for _, want := range []string{"table", "json", "yaml"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("completions missing %q:\n%s", want, out)
+		}
+	}
+
+	if err := app.RegisterCompleter("missing", nil); err == nil {
+		t.Error("RegisterCompleter accepted an unknown flag")
+	}
+}
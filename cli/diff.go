@@ -0,0 +1,258 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DiffOptions configures Diff and PrintDiff.
+type DiffOptions struct {
+	// ContextLines is the number of unchanged lines kept around each change
+	// in compact output (default: 3).
+	ContextLines int
+	// FromFile and ToFile label the ---/+++ header. The header is omitted
+	// when both are empty.
+	FromFile string
+	ToFile   string
+	// Compact shows only changed regions as @@ hunks instead of the whole
+	// text.
+	Compact bool
+}
+
+var (
+	styleDiffAdd    = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("22"))
+	styleDiffDelete = lipgloss.NewStyle().Foreground(lipgloss.Color("15")).Background(lipgloss.Color("52"))
+	styleDiffHunk   = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+)
+
+type diffOp struct {
+	kind byte // ' ', '-', or '+'
+	text string
+}
+
+// PrintDiff prints a colorized line diff from a to b on stdout.
+//
+// Example:
+//
+//	cli.PrintDiff(oldConfig, newConfig, cli.DiffOptions{
+//	    FromFile: "config.yaml", ToFile: "config.yaml (new)", Compact: true,
+//	})
+func PrintDiff(a, b string, opts DiffOptions) {
+	fmt.Fprint(os.Stdout, Diff(a, b, opts))
+}
+
+// PrintJSONDiff prints the diff between a and b encoded as indented JSON.
+func PrintJSONDiff(a, b any) error {
+	left, err := json.MarshalIndent(a, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	right, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	PrintDiff(string(left), string(right), DiffOptions{Compact: true})
+	return nil
+}
+
+// Diff returns the colorized diff that PrintDiff prints. Removed lines start
+// with "-", added lines with "+", and unchanged lines with a space. Identical
+// inputs produce "". When the changed region between the common prefix and
+// suffix is too large to align (about 2000 lines on each side), it is shown
+// as every old line removed followed by every new line added.
+func Diff(a, b string, opts DiffOptions) string {
+	ops := diffLines(splitLines(a), splitLines(b))
+	changed := false
+	for _, op := range ops {
+		if op.kind != ' ' {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	var sb strings.Builder
+	if opts.FromFile != "" || opts.ToFile != "" {
+		sb.WriteString(styleDiffDelete.Render("--- "+opts.FromFile) + "\n")
+		sb.WriteString(styleDiffAdd.Render("+++ "+opts.ToFile) + "\n")
+	}
+	if !opts.Compact {
+		writeDiffOps(&sb, ops)
+		return sb.String()
+	}
+
+	context := opts.ContextLines
+	if context <= 0 {
+		context = 3
+	}
+	for _, h := range diffHunks(ops, context) {
+		sb.WriteString(styleDiffHunk.Render(fmt.Sprintf("@@ -%d,%d +%d,%d @@", h.aStart, h.aLen, h.bStart, h.bLen)) + "\n")
+		writeDiffOps(&sb, ops[h.from:h.to])
+	}
+	return sb.String()
+}
+
+func writeDiffOps(sb *strings.Builder, ops []diffOp) {
+	for _, op := range ops {
+		line := string(op.kind) + op.text
+		switch op.kind {
+		case '-':
+			line = styleDiffDelete.Render(line)
+		case '+':
+			line = styleDiffAdd.Render(line)
+		}
+		sb.WriteString(line)
+		sb.WriteByte('\n')
+	}
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// maxDiffCells caps the LCS table at about 32 MiB. Larger changed regions
+// are shown as a removal of every old line followed by every new one.
+const maxDiffCells = 1 << 22
+
+// diffLines computes a line diff from the longest common subsequence of
+// the lines between the common prefix and suffix.
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	if (len(midA)+1)*(len(midB)+1) > maxDiffCells {
+		for _, line := range midA {
+			ops = append(ops, diffOp{'-', line})
+		}
+		for _, line := range midB {
+			ops = append(ops, diffOp{'+', line})
+		}
+	} else {
+		ops = appendLCSOps(ops, midA, midB)
+	}
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops
+}
+
+// appendLCSOps appends the diff of midA and midB found by dynamic
+// programming over their longest common subsequence.
+func appendLCSOps(ops []diffOp, midA, midB []string) []diffOp {
+	// lcs[i][j] is the LCS length of midA[i:] and midB[j:].
+	lcs := make([][]int, len(midA)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(midB)+1)
+	}
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			if midA[i] == midB[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(midA) || j < len(midB) {
+		switch {
+		case i < len(midA) && j < len(midB) && midA[i] == midB[j]:
+			ops = append(ops, diffOp{' ', midA[i]})
+			i++
+			j++
+		case j == len(midB) || (i < len(midA) && lcs[i+1][j] >= lcs[i][j+1]):
+			ops = append(ops, diffOp{'-', midA[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', midB[j]})
+			j++
+		}
+	}
+	return ops
+}
+
+type diffHunk struct {
+	from, to     int // op range
+	aStart, aLen int
+	bStart, bLen int
+}
+
+// diffHunks groups changes with up to context unchanged lines around them.
+// Changes separated by at most 2*context unchanged lines share a hunk.
+func diffHunks(ops []diffOp, context int) []diffHunk {
+	var hunks []diffHunk
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+		from := max(i-context, 0)
+		to := i
+		for to < len(ops) {
+			if ops[to].kind != ' ' {
+				to++
+				continue
+			}
+			run := to
+			for run < len(ops) && ops[run].kind == ' ' {
+				run++
+			}
+			if run == len(ops) || run-to > 2*context {
+				to = min(to+context, len(ops))
+				break
+			}
+			to = run
+		}
+		hunks = append(hunks, newDiffHunk(ops, from, to))
+		i = to - 1
+	}
+	return hunks
+}
+
+func newDiffHunk(ops []diffOp, from, to int) diffHunk {
+	h := diffHunk{from: from, to: to, aStart: 1, bStart: 1}
+	for _, op := range ops[:from] {
+		if op.kind != '+' {
+			h.aStart++
+		}
+		if op.kind != '-' {
+			h.bStart++
+		}
+	}
+	for _, op := range ops[from:to] {
+		if op.kind != '+' {
+			h.aLen++
+		}
+		if op.kind != '-' {
+			h.bLen++
+		}
+	}
+	// An empty side is numbered by the line before it, as in diff -u.
+	if h.aLen == 0 {
+		h.aStart--
+	}
+	if h.bLen == 0 {
+		h.bStart--
+	}
+	return h
+}
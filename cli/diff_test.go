@@ -0,0 +1,96 @@
+package cli_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+func TestDiff_Full(t *testing.T) {
+	t.Parallel()
+
+	got := cli.Diff("a\nb\nc\n", "a\nB\nc\nd\n", cli.DiffOptions{FromFile: "old.txt", ToFile: "new.txt"})
+	want := "--- old.txt\n+++ new.txt\n a\n-b\n+B\n c\n+d\n"
+	if got != want {
+		t.Errorf("Diff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiff_CompactHunks(t *testing.T) {
+	t.Parallel()
+
+	var a, b []string
+	for i := 1; i <= 20; i++ {
+		line := "line " + string(rune('a'+i-1))
+		a = append(a, line)
+		switch i {
+		case 3:
+			b = append(b, "changed c")
+		case 18:
+			// deleted
+		default:
+			b = append(b, line)
+		}
+	}
+	got := cli.Diff(strings.Join(a, "\n"), strings.Join(b, "\n"), cli.DiffOptions{ContextLines: 1, Compact: true})
+	want := strings.Join([]string{
+		"@@ -2,3 +2,3 @@",
+		" line b",
+		"-line c",
+		"+changed c",
+		" line d",
+		"@@ -17,3 +17,2 @@",
+		" line q",
+		"-line r",
+		" line s",
+		"",
+	}, "\n")
+	if got != want {
+		t.Errorf("Diff =\n%s\nwant\n%s", got, want)
+	}
+	if strings.Contains(got, "line j") {
+		t.Error("compact diff includes unchanged middle section")
+	}
+}
+
+func TestDiff_MergesNearbyChanges(t *testing.T) {
+	t.Parallel()
+
+	got := cli.Diff("1\n2\n3\n4\n5\n", "1\nX\n3\nY\n5\n", cli.DiffOptions{ContextLines: 1, Compact: true})
+	if strings.Count(got, "@@ -") != 1 {
+		t.Errorf("nearby changes split into several hunks:\n%s", got)
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	t.Parallel()
+
+	if got := cli.Diff("same\n", "same\n", cli.DiffOptions{Compact: true}); got != "" {
+		t.Errorf("Diff of identical input = %q, want empty", got)
+	}
+	if got := cli.Diff("", "new\n", cli.DiffOptions{Compact: true}); got != "@@ -0,0 +1,1 @@\n+new\n" {
+		t.Errorf("Diff from empty = %q", got)
+	}
+}
+
+func TestDiff_LargeInputsFallBackToReplace(t *testing.T) {
+	t.Parallel()
+
+	var a, b []string
+	for i := range 2100 {
+		a = append(a, fmt.Sprintf("old %d", i))
+		b = append(b, fmt.Sprintf("new %d", i))
+	}
+	a = append([]string{"same"}, a...)
+	b = append([]string{"same"}, b...)
+	got := cli.Diff(strings.Join(a, "\n"), strings.Join(b, "\n"), cli.DiffOptions{})
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 1+2*2100 {
+		t.Fatalf("diff has %d lines, want %d", len(lines), 1+2*2100)
+	}
+	if lines[0] != " same" || !strings.HasSuffix(lines[2100], "-old 2099") || !strings.HasSuffix(lines[2101], "+new 0") {
+		t.Fatalf("unexpected fallback layout: %q, %q, %q", lines[0], lines[2100], lines[2101])
+	}
+}
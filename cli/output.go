@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+// Output formats accepted by the --output flag.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+)
+
+// TableRower is implemented by values that Print can render as a table.
+type TableRower interface {
+	TableRows() (headers []string, rows [][]string)
+}
+
+// WithOutputFlag adds a persistent --output/-o flag selecting table, json,
+// or yaml output for Print and OutputFormat.
+func (a *App) WithOutputFlag() *App {
+	a.root.PersistentFlags().StringP("output", "o", OutputTable, "output format (table, json, yaml)")
+	return a
+}
+
+// OutputFormat returns the lower-cased --output value, or "table" when the
+// flag is unset or not registered.
+func OutputFormat(cmd *cobra.Command) string {
+	format, err := cmd.Flags().GetString("output")
+	if err != nil || format == "" {
+		return OutputTable
+	}
+	return strings.ToLower(format)
+}
+
+// Print writes data to the command's output in the --output format. Table
+// output requires data to implement TableRower; JSON and YAML use the
+// encoding/json and yaml struct tags respectively.
+//
+// Example:
+//
+//	return cli.Print(cmd, services)
+func Print[T any](cmd *cobra.Command, data T) error {
+	w := cmd.OutOrStdout()
+	format := OutputFormat(cmd)
+	if format != OutputTable {
+		return encodeOutput(w, format, data)
+	}
+	rower, ok := any(data).(TableRower)
+	if !ok {
+		return fmt.Errorf("%T cannot be printed as a table; use --output json or yaml", data)
+	}
+	headers, rows := rower.TableRows()
+	t := NewTable(headers...).SetWriter(w)
+	for _, row := range rows {
+		t.AddRow(row...)
+	}
+	t.Print()
+	return nil
+}
+
+// PrintAs renders the table in format. JSON and YAML output is a list of
+// objects keyed by header, in column order.
+func (t *Table) PrintAs(format string) error {
+	switch strings.ToLower(format) {
+	case OutputTable, "":
+		t.Print()
+		return nil
+	case OutputJSON:
+		return t.printJSON()
+	case OutputYAML:
+		return t.printYAML()
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
+
+func (t *Table) printJSON() error {
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, row := range t.rows {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('{')
+		for j, header := range t.headers {
+			if j > 0 {
+				buf.WriteByte(',')
+			}
+			key, _ := json.Marshal(header)
+			value, _ := json.Marshal(cell(row, j))
+			buf.Write(key)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		buf.WriteByte('}')
+	}
+	buf.WriteByte(']')
+
+	var out bytes.Buffer
+	if err := json.Indent(&out, buf.Bytes(), "", "  "); err != nil {
+		return fmt.Errorf("json encode: %w", err)
+	}
+	out.WriteByte('\n')
+	_, err := out.WriteTo(t.writer)
+	return err
+}
+
+func (t *Table) printYAML() error {
+	list := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, row := range t.rows {
+		record := &yaml.Node{Kind: yaml.MappingNode}
+		for j, header := range t.headers {
+			record.Content = append(record.Content,
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: header},
+				&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: cell(row, j)},
+			)
+		}
+		list.Content = append(list.Content, record)
+	}
+	return encodeOutput(t.writer, OutputYAML, list)
+}
+
+func cell(row []string, i int) string {
+	if i < len(row) {
+		return row[i]
+	}
+	return ""
+}
+
+func encodeOutput(w io.Writer, format string, data any) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("json encode: %w", err)
+		}
+		return nil
+	case OutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(data); err != nil {
+			return fmt.Errorf("yaml encode: %w", err)
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unsupported output format %q", format)
+	}
+}
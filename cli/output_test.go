@@ -0,0 +1,123 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+	"github.com/spf13/cobra"
+	"go.yaml.in/yaml/v3"
+)
+
+type service struct {
+	Name   string `json:"name" yaml:"name"`
+	Status string `json:"status" yaml:"status"`
+}
+
+type services []service
+
+func (s services) TableRows() ([]string, [][]string) {
+	rows := make([][]string, 0, len(s))
+	for _, svc := range s {
+		rows = append(rows, []string{svc.Name, svc.Status})
+	}
+	return []string{"NAME", "STATUS"}, rows
+}
+
+func runPrint(t *testing.T, data any, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	app := cli.NewApp("output-test", "0.0.0").WithOutputFlag()
+	app.AddCommand(cli.Command("list", "List services", func(cmd *cobra.Command, _ []string) error {
+		return cli.Print(cmd, data)
+	}))
+	app.Root().SetOut(&out)
+	if err := app.RunWithArgs(append([]string{"list"}, args...)); err != nil {
+		t.Fatalf("RunWithArgs(%v): %v", args, err)
+	}
+	return out.String()
+}
+
+func TestPrint_Formats(t *testing.T) {
+	t.Parallel()
+
+	data := services{{Name: "api", Status: "ready"}, {Name: "worker", Status: "stopped"}}
+
+	var fromJSON services
+	if err := json.Unmarshal([]byte(runPrint(t, data, "--output", "json")), &fromJSON); err != nil {
+		t.Fatalf("json output is invalid: %v", err)
+	}
+	if !reflect.DeepEqual(fromJSON, data) {
+		t.Errorf("json round trip = %+v", fromJSON)
+	}
+
+	var fromYAML services
+	if err := yaml.Unmarshal([]byte(runPrint(t, data, "-o", "yaml")), &fromYAML); err != nil {
+		t.Fatalf("yaml output is invalid: %v", err)
+	}
+	if !reflect.DeepEqual(fromYAML, data) {
+		t.Errorf("yaml round trip = %+v", fromYAML)
+	}
+
+	table := runPrint(t, data)
+	for _, want := range []string{"┌", "NAME", "STATUS", "worker", "stopped", "┘"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("table output missing %q:\n%s", want, table)
+		}
+	}
+}
+
+func TestPrint_TableRequiresRower(t *testing.T) {
+	t.Parallel()
+
+	app := cli.NewApp("output-test", "0.0.0").WithOutputFlag()
+	app.AddCommand(cli.Command("show", "Show", func(cmd *cobra.Command, _ []string) error {
+		return cli.Print(cmd, service{Name: "api"})
+	}))
+	app.Root().SetOut(&bytes.Buffer{})
+	app.Root().SetErr(&bytes.Buffer{})
+	if err := app.RunWithArgs([]string{"show"}); err == nil {
+		t.Fatal("table output of a non-TableRower succeeded")
+	}
+}
+
+func TestTable_PrintAs(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	tbl := cli.NewTable("NAME", "STATUS").AddRow("api", "ready").AddRow("worker").SetWriter(&out)
+
+	if err := tbl.PrintAs("json"); err != nil {
+		t.Fatalf("PrintAs(json): %v", err)
+	}
+	want := `[
+  {
+    "NAME": "api",
+    "STATUS": "ready"
+  },
+  {
+    "NAME": "worker",
+    "STATUS": ""
+  }
+]
+`
+	if out.String() != want {
+		t.Errorf("json = %s, want %s", out.String(), want)
+	}
+
+	out.Reset()
+	if err := tbl.PrintAs("yaml"); err != nil {
+		t.Fatalf("PrintAs(yaml): %v", err)
+	}
+	if want := "- NAME: api\n  STATUS: ready\n- NAME: worker\n  STATUS: \"\"\n"; out.String() != want {
+		t.Errorf("yaml = %q, want %q", out.String(), want)
+	}
+
+	if err := tbl.PrintAs("csv"); err == nil {
+		t.Error("PrintAs(csv) succeeded")
+	}
+}
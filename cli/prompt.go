@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+)
+
+// Prompter asks interactive questions on a reader/writer pair.
+// A Prompter keeps one buffered reader, so ask every question of a session
+// through the same Prompter.
+type Prompter struct {
+	reader         *bufio.Reader
+	writer         io.Writer
+	assumeYes      bool
+	nonInteractive bool
+}
+
+var (
+	stdinPrompter     *Prompter
+	stdinPrompterOnce sync.Once
+)
+
+// NewPrompter creates a prompter that reads stdin and writes stdout.
+//
+// Example:
+//
+//	ok, err := cli.NewPrompter().Confirm("Delete all records?")
+func NewPrompter() *Prompter {
+	return &Prompter{
+		reader: bufio.NewReader(os.Stdin),
+		writer: os.Stdout,
+	}
+}
+
+// PrompterFor creates a prompter on the command's input and output streams.
+// It honors the --yes and --no-interactive flags added by
+// App.WithPromptFlags.
+//
+// Example:
+//
+//	ok, err := cli.PrompterFor(cmd).Confirm("Drop the database?")
+//	if err != nil || !ok {
+//	    return err
+//	}
+func PrompterFor(cmd *cobra.Command) *Prompter {
+	p := &Prompter{
+		reader: bufio.NewReader(cmd.InOrStdin()),
+		writer: cmd.OutOrStdout(),
+	}
+	p.assumeYes, _ = cmd.Flags().GetBool("yes")
+	p.nonInteractive, _ = cmd.Flags().GetBool("no-interactive")
+	return p
+}
+
+// SetReader sets the input reader.
+func (p *Prompter) SetReader(r io.Reader) *Prompter {
+	p.reader = bufio.NewReader(r)
+	return p
+}
+
+// SetWriter sets the output writer.
+func (p *Prompter) SetWriter(w io.Writer) *Prompter {
+	p.writer = w
+	return p
+}
+
+// AssumeYes answers every confirmation with yes without reading input.
+func (p *Prompter) AssumeYes(yes bool) *Prompter {
+	p.assumeYes = yes
+	return p
+}
+
+// NonInteractive answers every question with its default without reading
+// input. Questions without a default return an error.
+func (p *Prompter) NonInteractive(enabled bool) *Prompter {
+	p.nonInteractive = enabled
+	return p
+}
+
+// Ask reads a free-form answer, trimmed of surrounding space. In
+// non-interactive mode it returns an error because there is no default.
+func (p *Prompter) Ask(prompt string) (string, error) {
+	if p.nonInteractive {
+		return "", fmt.Errorf("cannot ask %q in non-interactive mode", prompt)
+	}
+	return p.ask(prompt + ": ")
+}
+
+// Confirm asks a yes/no question that defaults to no.
+func (p *Prompter) Confirm(prompt string) (bool, error) {
+	return p.ConfirmWithDefault(prompt, false)
+}
+
+// ConfirmWithDefault asks a yes/no question. "y" and "yes" in any case
+// answer yes, "n" and "no" answer no, an empty line selects defaultYes, and
+// any other answer is no.
+func (p *Prompter) ConfirmWithDefault(prompt string, defaultYes bool) (bool, error) {
+	if p.assumeYes {
+		return true, nil
+	}
+	if p.nonInteractive {
+		return defaultYes, nil
+	}
+
+	hint := "[y/N]"
+	if defaultYes {
+		hint = "[Y/n]"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s %s ", prompt, hint))
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return defaultYes, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// ask writes prompt and returns the next trimmed input line. Input that ends
+// without a final newline is accepted; reaching EOF with no input is an error.
+func (p *Prompter) ask(prompt string) (string, error) {
+	fmt.Fprint(p.writer, prompt)
+	line, err := p.reader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", fmt.Errorf("read answer: %w", err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// stdinPrompt returns the shared process prompter so consecutive package-level
+// prompts do not lose buffered input.
+func stdinPrompt() *Prompter {
+	stdinPrompterOnce.Do(func() { stdinPrompter = NewPrompter() })
+	return stdinPrompter
+}
+
+// Confirm asks a yes/no question on stdin that defaults to no.
+//
+// Example:
+//
+//	ok, err := cli.Confirm("Delete 42 files?")
+//	if err != nil || !ok {
+//	    return err
+//	}
+func Confirm(prompt string) (bool, error) {
+	return stdinPrompt().Confirm(prompt)
+}
+
+// ConfirmWithDefault asks a yes/no question on stdin with the given default.
+func ConfirmWithDefault(prompt string, defaultYes bool) (bool, error) {
+	return stdinPrompt().ConfirmWithDefault(prompt, defaultYes)
+}
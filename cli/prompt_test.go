@@ -0,0 +1,110 @@
+package cli_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+	"github.com/spf13/cobra"
+)
+
+func TestPrompter_Confirm(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input      string
+		defaultYes bool
+		want       bool
+	}{
+		{input: "y\n", want: true},
+		{input: "Y\n", want: true},
+		{input: "yes\n", want: true},
+		{input: "YES\n", want: true},
+		{input: "  yes  \n", want: true},
+		{input: "n\n", want: false},
+		{input: "nope\n", want: false},
+		{input: "\n", want: false},
+		{input: "\n", defaultYes: true, want: true},
+		{input: "no\n", defaultYes: true, want: false},
+		{input: "y", want: true},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+		p := cli.NewPrompter().SetReader(strings.NewReader(tt.input)).SetWriter(&out)
+
+		got, err := p.ConfirmWithDefault("Continue?", tt.defaultYes)
+		if err != nil {
+			t.Fatalf("ConfirmWithDefault(%q, %v): %v", tt.input, tt.defaultYes, err)
+		}
+		if got != tt.want {
+			t.Errorf("ConfirmWithDefault(%q, %v) = %v, want %v", tt.input, tt.defaultYes, got, tt.want)
+		}
+		hint := "[y/N]"
+		if tt.defaultYes {
+			hint = "[Y/n]"
+		}
+		if out.String() != "Continue? "+hint+" " {
+			t.Errorf("prompt = %q", out.String())
+		}
+	}
+}
+
+func TestPrompter_ConfirmEOF(t *testing.T) {
+	t.Parallel()
+
+	p := cli.NewPrompter().SetReader(strings.NewReader("")).SetWriter(&bytes.Buffer{})
+	if ok, err := p.Confirm("Continue?"); err == nil || ok {
+		t.Fatalf("Confirm at EOF = %v, %v; want false and an error", ok, err)
+	}
+}
+
+func TestPrompter_SequentialAnswers(t *testing.T) {
+	t.Parallel()
+
+	p := cli.NewPrompter().SetReader(strings.NewReader("y\nn\n")).SetWriter(&bytes.Buffer{})
+	first, _ := p.Confirm("First?")
+	second, _ := p.Confirm("Second?")
+	if !first || second {
+		t.Errorf("answers = %v, %v; want true, false", first, second)
+	}
+}
+
+func TestPrompterFor_StandardFlags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{args: []string{"purge"}, want: false},
+		{args: []string{"purge", "--yes"}, want: true},
+		{args: []string{"purge", "-y"}, want: true},
+		{args: []string{"purge", "--no-interactive"}, want: false},
+	}
+
+	for _, tt := range tests {
+		var got bool
+		var out bytes.Buffer
+		app := cli.NewApp("prompt-test", "0.0.0").WithPromptFlags()
+		app.AddCommand(cli.Command("purge", "Purge data", func(cmd *cobra.Command, args []string) error {
+			var err error
+			got, err = cli.PrompterFor(cmd).Confirm("Purge?")
+			return err
+		}))
+		app.Root().SetIn(strings.NewReader("\n"))
+		app.Root().SetOut(&out)
+
+		if err := app.RunWithArgs(tt.args); err != nil {
+			t.Fatalf("RunWithArgs(%v): %v", tt.args, err)
+		}
+		if got != tt.want {
+			t.Errorf("RunWithArgs(%v) confirmed = %v, want %v", tt.args, got, tt.want)
+		}
+		prompted := strings.Contains(out.String(), "Purge? [y/N]")
+		if wantPrompt := len(tt.args) == 1; prompted != wantPrompt {
+			t.Errorf("RunWithArgs(%v) prompted = %v, want %v", tt.args, prompted, wantPrompt)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// SelectOption configures MultiSelect and SingleSelect.
+type SelectOption func(*selectConfig)
+
+type selectConfig struct {
+	defaults []int
+}
+
+// WithDefaults pre-selects options by zero-based index. An empty answer
+// accepts the defaults.
+func WithDefaults(indices []int) SelectOption {
+	return func(c *selectConfig) {
+		c.defaults = indices
+	}
+}
+
+// MultiSelect shows options as a numbered list and reads a comma-separated
+// answer of numbers and ranges such as "1,3" or "2-4". Selections are
+// returned in option order without duplicates. An empty answer returns the
+// defaults, or no selection when there are none.
+//
+// Example:
+//
+//	envs, err := p.MultiSelect("Deploy to", []string{"dev", "staging", "prod"},
+//	    cli.WithDefaults([]int{0}))
+func (p *Prompter) MultiSelect(prompt string, options []string, opts ...SelectOption) ([]string, error) {
+	selected, err := p.choose(prompt, options, true, opts)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, len(options))
+	for i, option := range options {
+		if selected[i] {
+			result = append(result, option)
+		}
+	}
+	return result, nil
+}
+
+// SingleSelect shows options as a numbered list and reads one number. An
+// empty answer returns the first default; without a default it is an error.
+func (p *Prompter) SingleSelect(prompt string, options []string, opts ...SelectOption) (string, error) {
+	selected, err := p.choose(prompt, options, false, opts)
+	if err != nil {
+		return "", err
+	}
+	for i, option := range options {
+		if selected[i] {
+			return option, nil
+		}
+	}
+	return "", errors.New("no option selected")
+}
+
+func (p *Prompter) choose(prompt string, options []string, multi bool, opts []SelectOption) (map[int]bool, error) {
+	if len(options) == 0 {
+		return nil, errors.New("no options to select from")
+	}
+	var cfg selectConfig
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	defaults := make(map[int]bool, len(cfg.defaults))
+	for _, i := range cfg.defaults {
+		if i < 0 || i >= len(options) {
+			return nil, fmt.Errorf("default index %d is out of range 0-%d", i, len(options)-1)
+		}
+		defaults[i] = true
+		if !multi {
+			break
+		}
+	}
+	if p.nonInteractive {
+		if !multi && len(defaults) == 0 {
+			return nil, errors.New("no default selection in non-interactive mode")
+		}
+		return defaults, nil
+	}
+
+	fmt.Fprintln(p.writer, prompt)
+	for i, option := range options {
+		marker := "[ ]"
+		if defaults[i] {
+			marker = "[x]"
+		}
+		fmt.Fprintf(p.writer, "  %d. %s %s\n", i+1, marker, option)
+	}
+	hint := "Enter a number"
+	if multi {
+		hint = "Enter numbers (e.g. 1,3 or 1-3)"
+	}
+	answer, err := p.ask(hint + ": ")
+	if err != nil {
+		return nil, err
+	}
+	if answer == "" {
+		if !multi && len(defaults) == 0 {
+			return nil, errors.New("no option selected")
+		}
+		return defaults, nil
+	}
+	if !multi {
+		n, err := parseChoice(answer, len(options))
+		if err != nil {
+			return nil, err
+		}
+		return map[int]bool{n: true}, nil
+	}
+	return parseChoices(answer, len(options))
+}
+
+// parseChoices parses a comma-separated list of one-based numbers and
+// inclusive ranges into zero-based indices.
+func parseChoices(answer string, count int) (map[int]bool, error) {
+	selected := make(map[int]bool)
+	for _, part := range strings.Split(answer, ",") {
+		part = strings.TrimSpace(part)
+		lo, hi, isRange := strings.Cut(part, "-")
+		if !isRange {
+			hi = lo
+		}
+		start, err := parseChoice(lo, count)
+		if err != nil {
+			return nil, err
+		}
+		end, err := parseChoice(hi, count)
+		if err != nil {
+			return nil, err
+		}
+		if start > end {
+			return nil, fmt.Errorf("invalid range %q: start is after end", part)
+		}
+		for i := start; i <= end; i++ {
+			selected[i] = true
+		}
+	}
+	return selected, nil
+}
+
+// parseChoice converts a one-based answer to a zero-based index.
+func parseChoice(s string, count int) (int, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid selection %q: not a number", s)
+	}
+	if n < 1 || n > count {
+		return 0, fmt.Errorf("selection %d is out of range 1-%d", n, count)
+	}
+	return n - 1, nil
+}
+
+// MultiSelect asks for several options on stdin. See Prompter.MultiSelect.
+func MultiSelect(prompt string, options []string, opts ...SelectOption) ([]string, error) {
+	return stdinPrompt().MultiSelect(prompt, options, opts...)
+}
+
+// SingleSelect asks for one option on stdin. See Prompter.SingleSelect.
+func SingleSelect(prompt string, options []string, opts ...SelectOption) (string, error) {
+	return stdinPrompt().SingleSelect(prompt, options, opts...)
+}
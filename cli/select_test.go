@@ -0,0 +1,118 @@
+package cli_test
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+var regions = []string{"us-east", "us-west", "eu-central", "ap-south", "sa-east"}
+
+func TestPrompter_MultiSelect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		defaults []int
+		want     []string
+		wantErr  string
+	}{
+		{name: "list", input: "1,3,5\n", want: []string{"us-east", "eu-central", "sa-east"}},
+		{name: "range", input: "1-3\n", want: []string{"us-east", "us-west", "eu-central"}},
+		{name: "mixed with spaces and duplicates", input: " 4, 2-3 ,2\n", want: []string{"us-west", "eu-central", "ap-south"}},
+		{name: "empty uses defaults", input: "\n", defaults: []int{1, 3}, want: []string{"us-west", "ap-south"}},
+		{name: "empty without defaults", input: "\n", want: []string{}},
+		{name: "out of range", input: "2,6\n", wantErr: "selection 6 is out of range 1-5"},
+		{name: "zero", input: "0\n", wantErr: "selection 0 is out of range 1-5"},
+		{name: "not a number", input: "one\n", wantErr: `invalid selection "one"`},
+		{name: "reversed range", input: "4-2\n", wantErr: `invalid range "4-2"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := cli.NewPrompter().SetReader(strings.NewReader(tt.input)).SetWriter(&bytes.Buffer{})
+			got, err := p.MultiSelect("Regions", regions, cli.WithDefaults(tt.defaults))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("MultiSelect: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MultiSelect = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompter_MultiSelectMarkers(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	p := cli.NewPrompter().SetReader(strings.NewReader("\n")).SetWriter(&out)
+	if _, err := p.MultiSelect("Regions", regions[:2], cli.WithDefaults([]int{1})); err != nil {
+		t.Fatalf("MultiSelect: %v", err)
+	}
+	want := "Regions\n  1. [ ] us-east\n  2. [x] us-west\nEnter numbers (e.g. 1,3 or 1-3): "
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestPrompter_SingleSelect(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		input    string
+		defaults []int
+		want     string
+		wantErr  bool
+	}{
+		{name: "number", input: "2\n", want: "us-west"},
+		{name: "default", input: "\n", defaults: []int{2}, want: "eu-central"},
+		{name: "empty without default", input: "\n", wantErr: true},
+		{name: "list rejected", input: "1,2\n", wantErr: true},
+		{name: "out of range", input: "9\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			p := cli.NewPrompter().SetReader(strings.NewReader(tt.input)).SetWriter(&bytes.Buffer{})
+			got, err := p.SingleSelect("Region", regions, cli.WithDefaults(tt.defaults))
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("SingleSelect = %q, want error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("SingleSelect = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPrompter_SelectNonInteractive(t *testing.T) {
+	t.Parallel()
+
+	p := cli.NewPrompter().SetReader(strings.NewReader("")).SetWriter(&bytes.Buffer{}).NonInteractive(true)
+	got, err := p.SingleSelect("Region", regions, cli.WithDefaults([]int{4}))
+	if err != nil || got != "sa-east" {
+		t.Errorf("SingleSelect = %q, %v; want sa-east", got, err)
+	}
+	if _, err := p.SingleSelect("Region", regions); err == nil {
+		t.Error("SingleSelect without default succeeded in non-interactive mode")
+	}
+}
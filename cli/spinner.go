@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
@@ -207,6 +208,9 @@ type Progress struct {
 	message string
 	writer  io.Writer
 	mu      sync.Mutex
+	// forceTTY, when true, renders intermediate states regardless of TTY
+	// detection. Used only by tests.
+	forceTTY bool
 }
 
 // NewProgress creates a progress indicator.
@@ -235,10 +239,15 @@ func (p *Progress) SetWriter(w io.Writer) *Progress {
 
 // Increment advances the progress by 1.
 func (p *Progress) Increment() {
+	p.Add(1)
+}
+
+// Add advances the progress by n.
+func (p *Progress) Add(n int) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	p.current++
+	p.current += n
 	p.render()
 }
 
@@ -251,34 +260,88 @@ func (p *Progress) Set(current int) {
 	p.render()
 }
 
-// render draws the progress bar.
+// SetTotal changes the total, for work whose size is discovered while it runs.
+func (p *Progress) SetTotal(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.total = total
+	p.render()
+}
+
+// SetMessage changes the label shown before the bar.
+func (p *Progress) SetMessage(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.message = message
+	p.render()
+}
+
+// render redraws the bar in place on a terminal. In non-TTY output,
+// intermediate states are skipped so logs only receive the final line.
 func (p *Progress) render() {
-	pct := float64(p.current) / float64(p.total) * 100
-	barWidth := 30
-	filled := int(float64(barWidth) * float64(p.current) / float64(p.total))
-
-	bar := ""
-	for i := 0; i < barWidth; i++ {
-		if i < filled {
-			bar += "█"
-		} else {
-			bar += "░"
-		}
+	if !p.forceTTY && !isTerminal(p.writer) {
+		return
 	}
+	fmt.Fprintf(p.writer, "\r%s", p.line())
+}
 
-	prefix := "\r"
-	if !isTerminal(p.writer) {
-		prefix = ""
+// line formats the progress bar for the current state.
+func (p *Progress) line() string {
+	const barWidth = 30
+
+	current := min(max(p.current, 0), p.total)
+	pct := 100.0
+	filled := barWidth
+	if p.total > 0 {
+		pct = float64(current) / float64(p.total) * 100
+		filled = barWidth * current / p.total
 	}
-	fmt.Fprintf(p.writer, "%s%s [%s] %d/%d (%.0f%%)", prefix, p.message, bar, p.current, p.total, pct)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return fmt.Sprintf("%s [%s] %d/%d (%.0f%%)", p.message, bar, p.current, p.total, pct)
 }
 
-// Done completes the progress and moves to a new line.
+// Done completes the progress and moves to a new line. In non-TTY output
+// this writes the only line.
 func (p *Progress) Done() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
 	p.current = p.total
+	prefix := "\r"
+	if !p.forceTTY && !isTerminal(p.writer) {
+		prefix = ""
+	}
+	fmt.Fprintf(p.writer, "%s%s\n", prefix, p.line())
+}
+
+// WithProgress runs fn with a progress bar on stdout, completing the bar on
+// success and printing an error otherwise.
+//
+// Example:
+//
+//	err := cli.WithProgress("Importing", len(files), func(p *cli.Progress) error {
+//	    for _, f := range files {
+//	        if err := importFile(f); err != nil {
+//	            return err
+//	        }
+//	        p.Increment()
+//	    }
+//	    return nil
+//	})
+func WithProgress(message string, total int, fn func(*Progress) error) error {
+	p := NewProgress(message, total)
 	p.render()
-	fmt.Fprintln(p.writer)
+
+	if err := fn(p); err != nil {
+		if isTerminal(p.writer) {
+			fmt.Fprintln(p.writer)
+		}
+		Error("%s failed: %v", message, err)
+		return err
+	}
+
+	p.Done()
+	return nil
 }
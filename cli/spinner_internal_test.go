@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"bytes"
 	"context"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -54,3 +56,53 @@ func TestSpinner_StopHaltsFramesPromptly(t *testing.T) {
 		t.Errorf("frame written after Stop(): count went %d -> %d", after, final)
 	}
 }
+
+func TestProgress_NonTTYWritesOnlyFinalLine(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewProgress("Importing", 4).SetWriter(&buf)
+	p.Increment()
+	p.Add(2)
+	p.SetMessage("Imported")
+	if buf.Len() != 0 {
+		t.Fatalf("non-TTY output before Done: %q", buf.String())
+	}
+
+	p.Done()
+	want := "Imported [" + strings.Repeat("█", 30) + "] 4/4 (100%)\n"
+	if buf.String() != want {
+		t.Errorf("Done output = %q, want %q", buf.String(), want)
+	}
+}
+
+func TestProgress_TTYRendersInPlace(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p := NewProgress("Copying", 10).SetWriter(&buf)
+	p.forceTTY = true
+
+	p.Add(5)
+	half := "\rCopying [" + strings.Repeat("█", 15) + strings.Repeat("░", 15) + "] 5/10 (50%)"
+	if buf.String() != half {
+		t.Fatalf("render = %q, want %q", buf.String(), half)
+	}
+
+	buf.Reset()
+	p.SetTotal(20)
+	p.Done()
+	if !strings.HasSuffix(buf.String(), "] 20/20 (100%)\n") {
+		t.Errorf("Done output = %q, want bar at 100%%", buf.String())
+	}
+}
+
+func TestProgress_ZeroTotal(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	NewProgress("Nothing", 0).SetWriter(&buf).Done()
+	if strings.Contains(buf.String(), "NaN") || !strings.Contains(buf.String(), "0/0 (100%)") {
+		t.Errorf("zero total output = %q", buf.String())
+	}
+}
@@ -5,17 +5,40 @@ import (
 	"io"
 	"os"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/charmbracelet/lipgloss/table"
 )
 
+// TableStyle controls how a table is drawn. Empty colors leave the
+// terminal default.
+type TableStyle struct {
+	Border                 lipgloss.Border
+	HeaderBold             bool
+	HeaderForeground       lipgloss.Color
+	HeaderBackground       lipgloss.Color
+	CellForeground         lipgloss.Color
+	RowAlternateBackground lipgloss.Color
+	BorderColor            lipgloss.Color
+}
+
+// Predefined table styles. TableStyleMarkdown renders a GitHub-Flavored
+// Markdown table without colors, suitable for pasting into issues and docs.
+var (
+	TableStyleDefault  = TableStyle{Border: lipgloss.NormalBorder(), HeaderBold: true, HeaderForeground: "12", BorderColor: "8"}
+	TableStyleRounded  = TableStyle{Border: lipgloss.RoundedBorder(), HeaderBold: true, HeaderForeground: "12", BorderColor: "8"}
+	TableStyleCompact  = TableStyle{Border: lipgloss.HiddenBorder(), HeaderBold: true, HeaderForeground: "12"}
+	TableStyleMarkdown = TableStyle{Border: lipgloss.MarkdownBorder()}
+)
+
 // Table builds styled terminal tables.
 type Table struct {
 	headers []string
 	rows    [][]string
 	writer  io.Writer
+	style   TableStyle
 }
 
 // NewTable creates a new table with headers.
@@ -31,15 +54,101 @@ func NewTable(headers ...string) *Table {
 		headers: headers,
 		rows:    make([][]string, 0),
 		writer:  os.Stdout,
+		style:   TableStyleDefault,
 	}
 }
 
+// Style sets the table style (default: TableStyleDefault).
+//
+// Example:
+//
+//	cli.NewTable("NAME", "STATUS").Style(cli.TableStyleMarkdown)
+func (t *Table) Style(style TableStyle) *Table {
+	t.style = style
+	return t
+}
+
 // AddRow adds a row to the table.
 func (t *Table) AddRow(values ...string) *Table {
 	t.rows = append(t.rows, values)
 	return t
 }
 
+// SortByColumn sorts rows by the text in column colIndex. The sort is
+// stable, so rows with equal values keep their insertion order. It panics
+// when colIndex is not a header index.
+//
+// Example:
+//
+//	t.SortByColumn(1, true).Print()
+func (t *Table) SortByColumn(colIndex int, ascending bool) *Table {
+	t.checkColumn("SortByColumn", colIndex)
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, b := cell(t.rows[i], colIndex), cell(t.rows[j], colIndex)
+		if ascending {
+			return a < b
+		}
+		return a > b
+	})
+	return t
+}
+
+// SortByColumnNumeric sorts rows by column colIndex parsed as float64.
+// Values that do not parse sort after all numbers in either direction. It
+// panics when colIndex is not a header index.
+func (t *Table) SortByColumnNumeric(colIndex int, ascending bool) *Table {
+	t.checkColumn("SortByColumnNumeric", colIndex)
+	sort.SliceStable(t.rows, func(i, j int) bool {
+		a, aErr := strconv.ParseFloat(strings.TrimSpace(cell(t.rows[i], colIndex)), 64)
+		b, bErr := strconv.ParseFloat(strings.TrimSpace(cell(t.rows[j], colIndex)), 64)
+		switch {
+		case aErr != nil || bErr != nil:
+			return aErr == nil && bErr != nil
+		case ascending:
+			return a < b
+		default:
+			return a > b
+		}
+	})
+	return t
+}
+
+// Filter returns a new table with the same headers and writer holding only
+// the rows for which fn returns true. The original table is unchanged.
+//
+// Example:
+//
+//	failed := t.Filter(func(row []string) bool { return row[2] != "ok" })
+func (t *Table) Filter(fn func(row []string) bool) *Table {
+	filtered := &Table{headers: t.headers, rows: make([][]string, 0), writer: t.writer, style: t.style}
+	for _, row := range t.rows {
+		if fn(row) {
+			filtered.rows = append(filtered.rows, row)
+		}
+	}
+	return filtered
+}
+
+// FilterByColumn returns a new table with the rows whose column colIndex
+// equals value. It panics when colIndex is not a header index.
+func (t *Table) FilterByColumn(colIndex int, value string) *Table {
+	t.checkColumn("FilterByColumn", colIndex)
+	return t.Filter(func(row []string) bool { return cell(row, colIndex) == value })
+}
+
+// FilterByColumnContains returns a new table with the rows whose column
+// colIndex contains substr. It panics when colIndex is not a header index.
+func (t *Table) FilterByColumnContains(colIndex int, substr string) *Table {
+	t.checkColumn("FilterByColumnContains", colIndex)
+	return t.Filter(func(row []string) bool { return strings.Contains(cell(row, colIndex), substr) })
+}
+
+func (t *Table) checkColumn(method string, colIndex int) {
+	if colIndex < 0 || colIndex >= len(t.headers) {
+		panic(fmt.Sprintf("cli: Table.%s: column index %d out of range for %d columns", method, colIndex, len(t.headers)))
+	}
+}
+
 // SetWriter sets the output writer.
 func (t *Table) SetWriter(w io.Writer) *Table {
 	t.writer = w
@@ -52,29 +161,62 @@ func (t *Table) Print() {
 		return
 	}
 
+	style := t.style
+	border := style.Border
+	if border == (lipgloss.Border{}) {
+		border = lipgloss.NormalBorder()
+	}
+	markdown := border == lipgloss.MarkdownBorder()
+
 	headerStyle := lipgloss.NewStyle().
-		Bold(true).
-		Foreground(lipgloss.Color("12")).
+		Bold(style.HeaderBold).
+		Foreground(style.HeaderForeground).
+		Background(style.HeaderBackground).
 		Padding(0, 1)
-
 	cellStyle := lipgloss.NewStyle().
+		Foreground(style.CellForeground).
 		Padding(0, 1)
+	alternateStyle := cellStyle.Background(style.RowAlternateBackground)
+
+	headers, rows := t.headers, t.rows
+	if markdown {
+		headers, rows = escapeMarkdownCells(headers), make([][]string, len(t.rows))
+		for i, row := range t.rows {
+			rows[i] = escapeMarkdownCells(row)
+		}
+	}
 
 	tbl := table.New().
-		Border(lipgloss.NormalBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(lipgloss.Color("8"))).
+		Border(border).
+		BorderTop(!markdown).
+		BorderBottom(!markdown).
+		BorderStyle(lipgloss.NewStyle().Foreground(style.BorderColor)).
 		StyleFunc(func(row, col int) lipgloss.Style {
-			if row == 0 {
+			switch {
+			case row == table.HeaderRow:
 				return headerStyle
+			case row%2 == 1 && style.RowAlternateBackground != "":
+				return alternateStyle
+			default:
+				return cellStyle
 			}
-			return cellStyle
 		}).
-		Headers(t.headers...).
-		Rows(t.rows...)
+		Headers(headers...).
+		Rows(rows...)
 
 	fmt.Fprintln(t.writer, tbl)
 }
 
+// escapeMarkdownCells escapes pipes so cell text cannot split a Markdown
+// table column.
+func escapeMarkdownCells(cells []string) []string {
+	escaped := make([]string, len(cells))
+	for i, c := range cells {
+		escaped[i] = strings.ReplaceAll(c, "|", `\|`)
+	}
+	return escaped
+}
+
 // String returns the table as a string.
 func (t *Table) String() string {
 	var sb strings.Builder
@@ -0,0 +1,151 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+// assertRowOrder fails unless each value appears in out after the previous one.
+func assertRowOrder(t *testing.T, out string, values ...string) {
+	t.Helper()
+	last := -1
+	for _, value := range values {
+		i := strings.Index(out, value)
+		if i < 0 {
+			t.Fatalf("output missing %q:\n%s", value, out)
+		}
+		if i < last {
+			t.Fatalf("%q rendered out of order in:\n%s", value, out)
+		}
+		last = i
+	}
+}
+
+func TestTable_SortByColumn(t *testing.T) {
+	t.Parallel()
+
+	tbl := cli.NewTable("NAME", "SIZE").
+		AddRow("carol", "10").
+		AddRow("alice", "9").
+		AddRow("bob", "100")
+
+	assertRowOrder(t, tbl.SortByColumn(0, true).String(), "alice", "bob", "carol")
+	assertRowOrder(t, tbl.SortByColumn(0, false).String(), "carol", "bob", "alice")
+	// Text order puts "10" before "9"; numeric order does not.
+	assertRowOrder(t, tbl.SortByColumn(1, true).String(), "carol", "bob", "alice")
+	assertRowOrder(t, tbl.SortByColumnNumeric(1, true).String(), "alice", "carol", "bob")
+	assertRowOrder(t, tbl.SortByColumnNumeric(1, false).String(), "bob", "carol", "alice")
+}
+
+func TestTable_SortByColumnNumericPutsTextLast(t *testing.T) {
+	t.Parallel()
+
+	tbl := cli.NewTable("NAME", "SIZE").
+		AddRow("unknown", "n/a").
+		AddRow("big", "2.5").
+		AddRow("small", "-1")
+
+	assertRowOrder(t, tbl.SortByColumnNumeric(1, false).String(), "big", "small", "unknown")
+}
+
+func TestTable_SortByColumnPanicsOnBadIndex(t *testing.T) {
+	t.Parallel()
+
+	defer func() {
+		r := recover()
+		if msg, ok := r.(string); !ok || !strings.Contains(msg, "column index 2 out of range") {
+			t.Fatalf("panic = %v, want out-of-range message", r)
+		}
+	}()
+	cli.NewTable("NAME", "SIZE").SortByColumn(2, true)
+}
+
+// tableIDs returns the ID column of tbl, read back through PrintAs("json").
+func tableIDs(t *testing.T, tbl *cli.Table) []string {
+	t.Helper()
+	var out bytes.Buffer
+	if err := tbl.SetWriter(&out).PrintAs("json"); err != nil {
+		t.Fatalf("PrintAs(json): %v", err)
+	}
+	var rows []map[string]string
+	if err := json.Unmarshal(out.Bytes(), &rows); err != nil {
+		t.Fatalf("decode %s: %v", out.String(), err)
+	}
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row["ID"]
+	}
+	return ids
+}
+
+func TestTable_Filter(t *testing.T) {
+	t.Parallel()
+
+	tbl := cli.NewTable("ID", "STATUS")
+	for i := range 10 {
+		status := "ready"
+		if i%3 == 0 {
+			status = "failed: timeout"
+		}
+		tbl.AddRow(fmt.Sprintf("svc-%d", i), status)
+	}
+
+	failed := tbl.FilterByColumnContains(1, "failed")
+	ready := tbl.FilterByColumn(1, "ready")
+	low := tbl.Filter(func(row []string) bool { return row[0] < "svc-3" })
+
+	if got, want := tableIDs(t, failed), []string{"svc-0", "svc-3", "svc-6", "svc-9"}; !slices.Equal(got, want) {
+		t.Errorf("FilterByColumnContains = %v, want %v", got, want)
+	}
+	if got := tableIDs(t, ready); len(got) != 6 {
+		t.Errorf("FilterByColumn kept %v, want 6 rows", got)
+	}
+	if got, want := tableIDs(t, low), []string{"svc-0", "svc-1", "svc-2"}; !slices.Equal(got, want) {
+		t.Errorf("Filter = %v, want %v", got, want)
+	}
+	if got := tableIDs(t, tbl); len(got) != 10 {
+		t.Errorf("original table has %d rows after filtering, want 10", len(got))
+	}
+}
+
+func TestTable_StyleMarkdown(t *testing.T) {
+	t.Parallel()
+
+	out := cli.NewTable("A", "STATUS").
+		AddRow("api", "ready").
+		AddRow("worker", "a|b").
+		Style(cli.TableStyleMarkdown).
+		String()
+
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("markdown table has %d lines, want header, separator, and 2 rows:\n%s", len(lines), out)
+	}
+	for _, line := range lines {
+		if !strings.HasPrefix(line, "|") || !strings.HasSuffix(line, "|") {
+			t.Fatalf("line %q is not a pipe-delimited row:\n%s", line, out)
+		}
+	}
+	if !regexp.MustCompile(`^\|(-+\|)+$`).MatchString(lines[1]) {
+		t.Fatalf("separator = %q, want a GFM delimiter row", lines[1])
+	}
+	if !strings.Contains(lines[3], `a\|b`) {
+		t.Fatalf("pipe in cell not escaped: %q", lines[3])
+	}
+}
+
+func TestTable_StyleRounded(t *testing.T) {
+	t.Parallel()
+
+	out := cli.NewTable("NAME").AddRow("api").Style(cli.TableStyleRounded).String()
+	if !strings.HasPrefix(out, "╭") {
+		t.Fatalf("rounded table starts with %q, want ╭:\n%s", []rune(out)[0], out)
+	}
+}
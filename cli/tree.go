@@ -0,0 +1,160 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Tree renders a hierarchy with box-drawing branches.
+type Tree struct {
+	root   *treeNode
+	nodes  map[string]*treeNode
+	writer io.Writer
+}
+
+type treeNode struct {
+	label    string
+	children []*treeNode
+}
+
+// NewTree creates a tree with a root label.
+//
+// Example:
+//
+//	cli.NewTree("myapp").
+//	    AddBranch("myapp", "cmd").
+//	    AddBranch("cmd", "main.go").
+//	    AddBranch("myapp", "go.mod").
+//	    Print()
+func NewTree(root string) *Tree {
+	node := &treeNode{label: root}
+	return &Tree{
+		root:   node,
+		nodes:  map[string]*treeNode{root: node},
+		writer: os.Stdout,
+	}
+}
+
+// TreeFromMap builds a tree from an adjacency map of parent label to child
+// labels. Children keep their slice order; labels already in the tree are
+// not added again, which also breaks cycles.
+func TreeFromMap(root string, children map[string][]string) *Tree {
+	t := NewTree(root)
+	queue := []string{root}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, child := range children[parent] {
+			if _, seen := t.nodes[child]; seen {
+				continue
+			}
+			t.AddBranch(parent, child)
+			queue = append(queue, child)
+		}
+	}
+	return t
+}
+
+// AddBranch adds child under the node labeled parent. Labels identify
+// nodes, so they must be unique within a tree; an unknown parent is first
+// added under the root.
+func (t *Tree) AddBranch(parent, child string) *Tree {
+	p, ok := t.nodes[parent]
+	if !ok {
+		p = &treeNode{label: parent}
+		t.nodes[parent] = p
+		t.root.children = append(t.root.children, p)
+	}
+	node := &treeNode{label: child}
+	t.nodes[child] = node
+	p.children = append(p.children, node)
+	return t
+}
+
+// SetWriter sets the output writer.
+func (t *Tree) SetWriter(w io.Writer) *Tree {
+	t.writer = w
+	return t
+}
+
+// Print renders the tree to the configured writer.
+func (t *Tree) Print() {
+	fmt.Fprint(t.writer, t.String())
+}
+
+// String returns the rendered tree, one node per line.
+func (t *Tree) String() string {
+	var sb strings.Builder
+	sb.WriteString(styleBold.Render(t.root.label))
+	sb.WriteByte('\n')
+	writeTreeChildren(&sb, t.root, "")
+	return sb.String()
+}
+
+func writeTreeChildren(sb *strings.Builder, node *treeNode, prefix string) {
+	for i, child := range node.children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		sb.WriteString(styleDim.Render(prefix + branch))
+		sb.WriteString(child.label)
+		sb.WriteByte('\n')
+		writeTreeChildren(sb, child, prefix+indent)
+	}
+}
+
+// PrintDir prints the directory structure under path to stdout, descending
+// at most depth levels (0 for no limit). Entries are sorted with
+// directories first and directory names end in "/".
+//
+// Example:
+//
+//	depth, _ := cmd.Flags().GetInt("depth")
+//	return cli.PrintDir(".", depth)
+func PrintDir(path string, depth int) error {
+	t, err := DirTree(path, depth)
+	if err != nil {
+		return err
+	}
+	t.Print()
+	return nil
+}
+
+// DirTree builds the tree that PrintDir prints.
+func DirTree(path string, depth int) (*Tree, error) {
+	t := NewTree(filepath.Clean(path))
+	if err := addDir(t.root, path, depth, 1); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func addDir(node *treeNode, dir string, depth, level int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("read dir: %w", err)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].IsDir() && !entries[j].IsDir()
+	})
+	for _, entry := range entries {
+		child := &treeNode{label: entry.Name()}
+		node.children = append(node.children, child)
+		if !entry.IsDir() {
+			continue
+		}
+		child.label += "/"
+		if depth > 0 && level >= depth {
+			continue
+		}
+		if err := addDir(child, filepath.Join(dir, entry.Name()), depth, level+1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+func TestTree_String(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	cli.NewTree("app").
+		AddBranch("app", "cmd").
+		AddBranch("cmd", "main.go").
+		AddBranch("cmd", "root.go").
+		AddBranch("app", "internal").
+		AddBranch("internal", "store.go").
+		AddBranch("app", "go.mod").
+		SetWriter(&out).
+		Print()
+
+	want := `app
+├── cmd
+│   ├── main.go
+│   └── root.go
+├── internal
+│   └── store.go
+└── go.mod
+`
+	if out.String() != want {
+		t.Errorf("tree =\n%s\nwant\n%s", out.String(), want)
+	}
+}
+
+func TestTreeFromMap(t *testing.T) {
+	t.Parallel()
+
+	got := cli.TreeFromMap("a", map[string][]string{
+		"a": {"b", "c"},
+		"b": {"d"},
+		"d": {"a"},
+	}).String()
+	want := "a\n├── b\n│   └── d\n└── c\n"
+	if got != want {
+		t.Errorf("tree =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDirTree_Depth(t *testing.T) {
+	t.Parallel()
+
+	root := t.TempDir()
+	for _, dir := range []string{"cmd/app", "internal"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, file := range []string{"go.mod", "cmd/app/main.go"} {
+		if err := os.WriteFile(filepath.Join(root, file), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tree, err := cli.DirTree(root, 2)
+	if err != nil {
+		t.Fatalf("DirTree: %v", err)
+	}
+	want := root + "\n├── cmd/\n│   └── app/\n├── internal/\n└── go.mod\n"
+	if got := tree.String(); got != want {
+		t.Errorf("tree =\n%s\nwant\n%s", got, want)
+	}
+
+	full, err := cli.DirTree(root, 0)
+	if err != nil {
+		t.Fatalf("DirTree: %v", err)
+	}
+	if !bytes.Contains([]byte(full.String()), []byte("│       └── main.go")) {
+		t.Errorf("unlimited depth missing nested file:\n%s", full.String())
+	}
+}
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	defaultUpdateInterval = 24 * time.Hour
+	updateCheckTimeout    = 5 * time.Second
+	// updateCheckWait bounds how long a finished command waits for a
+	// check that is still in flight.
+	updateCheckWait = 2 * time.Second
+	updateStateFile = "update-check.json"
+)
+
+// UpdateCheckConfig configures App.WithUpdateCheck.
+type UpdateCheckConfig struct {
+	RepoOwner string
+	RepoName  string
+	// Interval is the minimum time between checks (default: 24h).
+	Interval time.Duration
+	// Notifier is called when a newer release exists. The default prints a
+	// styled notice to the command's stderr.
+	Notifier func(current, latest string)
+	// BaseURL overrides the GitHub API endpoint (default: https://api.github.com).
+	BaseURL string
+	// Client is the HTTP client for the check (default: 5s timeout).
+	Client *http.Client
+}
+
+type updateState struct {
+	LastChecked time.Time `json:"last_checked"`
+}
+
+// WithUpdateCheck checks GitHub releases for a newer version while commands
+// run and reports it when Run or RunWithArgs returns without error. Checks
+// run at most once per Interval, whether or not they succeed, with the last
+// check time kept in update-check.json in the app's config directory. They
+// are skipped when the CI environment variable is set, when
+// --no-update-check is passed, and for versions that are not dotted numbers
+// such as "dev". The check starts from the root's PersistentPreRunE, which
+// cobra does not run for a subcommand that sets its own PersistentPreRun or
+// PersistentPreRunE; such commands skip the check, as they skip config
+// loading.
+//
+// Example:
+//
+//	app := cli.NewApp("myapp", version).WithUpdateCheck(cli.UpdateCheckConfig{
+//	    RepoOwner: "acme",
+//	    RepoName:  "myapp",
+//	})
+func (a *App) WithUpdateCheck(cfg UpdateCheckConfig) *App {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultUpdateInterval
+	}
+	if cfg.BaseURL == "" {
+		cfg.BaseURL = "https://api.github.com"
+	}
+	if cfg.Client == nil {
+		cfg.Client = &http.Client{Timeout: updateCheckTimeout}
+	}
+	a.root.PersistentFlags().Bool("no-update-check", false, "skip checking for a newer release")
+
+	preRun := a.root.PersistentPreRunE
+	a.root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := preRun(cmd, args); err != nil {
+			return err
+		}
+		if a.shouldCheckForUpdate(cmd, cfg) {
+			// Record the attempt first so an offline run does not retry on
+			// every command.
+			_ = saveUpdateState(a.name, updateState{LastChecked: time.Now()})
+			result := make(chan string, 1)
+			go func() { result <- latestRelease(cmd.Context(), cfg) }()
+			a.updateCheck = func() { a.reportUpdate(cmd, cfg, result) }
+		}
+		return nil
+	}
+	return a
+}
+
+// reportUpdate waits briefly for a pending check and reports a newer release.
+// Run and RunWithArgs call it after a successful command, so subcommands
+// with their own post-run hooks are covered too.
+func (a *App) reportUpdate(cmd *cobra.Command, cfg UpdateCheckConfig, result <-chan string) {
+	var latest string
+	select {
+	case latest = <-result:
+	case <-time.After(updateCheckWait):
+		return
+	}
+	if latest == "" || compareVersions(latest, a.version) <= 0 {
+		return
+	}
+	notify := cfg.Notifier
+	if notify == nil {
+		notify = func(current, latest string) {
+			msg := fmt.Sprintf("Update available: %s → %s", current, latest)
+			fmt.Fprintln(cmd.ErrOrStderr(), styleWarning.Render(msg))
+		}
+	}
+	notify(versionTag(a.version), versionTag(latest))
+}
+
+func (a *App) shouldCheckForUpdate(cmd *cobra.Command, cfg UpdateCheckConfig) bool {
+	if os.Getenv("CI") != "" {
+		return false
+	}
+	if skip, _ := cmd.Flags().GetBool("no-update-check"); skip {
+		return false
+	}
+	if _, ok := parseVersion(a.version); !ok {
+		return false
+	}
+	state, err := loadUpdateState(a.name)
+	return err != nil || time.Since(state.LastChecked) >= cfg.Interval
+}
+
+func updateStatePath(appName string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, appName, updateStateFile), nil
+}
+
+func loadUpdateState(appName string) (updateState, error) {
+	var state updateState
+	path, err := updateStatePath(appName)
+	if err != nil {
+		return state, err
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(content, &state)
+	return state, err
+}
+
+func saveUpdateState(appName string, state updateState) error {
+	path, err := updateStatePath(appName)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	content, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0o600)
+}
+
+// latestRelease returns the latest release tag, or "" when the check fails.
+// Failures are silent: an update notice must never break a command.
+func latestRelease(ctx context.Context, cfg UpdateCheckConfig) string {
+	url := fmt.Sprintf("%s/repos/%s/%s/releases/latest", strings.TrimRight(cfg.BaseURL, "/"), cfg.RepoOwner, cfg.RepoName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return ""
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := cfg.Client.Do(req)
+	if err != nil {
+		return ""
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return ""
+	}
+	var release struct {
+		TagName string `json:"tag_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return ""
+	}
+	return release.TagName
+}
+
+// compareVersions compares versions such as "v1.2.10" and "1.2.9-rc.1" by
+// semver precedence: dotted numbers first, then a release ranks above its
+// pre-releases, which compare identifier by identifier. Build metadata is
+// ignored. Unparseable versions compare as equal so they never trigger a
+// notice.
+func compareVersions(a, b string) int {
+	va, okA := parseVersion(a)
+	vb, okB := parseVersion(b)
+	if !okA || !okB {
+		return 0
+	}
+	for i := 0; i < max(len(va.nums), len(vb.nums)); i++ {
+		var x, y int
+		if i < len(va.nums) {
+			x = va.nums[i]
+		}
+		if i < len(vb.nums) {
+			y = vb.nums[i]
+		}
+		if x != y {
+			return cmp.Compare(x, y)
+		}
+	}
+	switch {
+	case va.pre == "" && vb.pre == "":
+		return 0
+	case va.pre == "":
+		return 1
+	case vb.pre == "":
+		return -1
+	}
+	pa, pb := strings.Split(va.pre, "."), strings.Split(vb.pre, ".")
+	for i := 0; i < min(len(pa), len(pb)); i++ {
+		if c := comparePrerelease(pa[i], pb[i]); c != 0 {
+			return c
+		}
+	}
+	return cmp.Compare(len(pa), len(pb))
+}
+
+// comparePrerelease orders numeric identifiers numerically and below
+// alphanumeric ones, which compare as strings.
+func comparePrerelease(a, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return cmp.Compare(x, y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+type releaseVersion struct {
+	nums []int
+	pre  string
+}
+
+func parseVersion(v string) (releaseVersion, bool) {
+	v = strings.TrimPrefix(strings.TrimSpace(v), "v")
+	v, _, _ = strings.Cut(v, "+")
+	v, pre, _ := strings.Cut(v, "-")
+	if v == "" {
+		return releaseVersion{}, false
+	}
+	parts := strings.Split(v, ".")
+	nums := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return releaseVersion{}, false
+		}
+		nums[i] = n
+	}
+	return releaseVersion{nums: nums, pre: pre}, true
+}
+
+func versionTag(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
@@ -0,0 +1,218 @@
+package cli_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+	"github.com/spf13/cobra"
+)
+
+type updateCheck struct {
+	server   *httptest.Server
+	requests atomic.Int32
+}
+
+func newReleaseServer(t *testing.T, tag string) *updateCheck {
+	t.Helper()
+
+	uc := &updateCheck{}
+	uc.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		uc.requests.Add(1)
+		if r.URL.Path != "/repos/acme/tool/releases/latest" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"tag_name":%q}`, tag)
+	}))
+	t.Cleanup(uc.server.Close)
+	return uc
+}
+
+// runUpdateApp runs one command and returns the notifications it produced.
+func runUpdateApp(t *testing.T, uc *updateCheck, version string, args ...string) []string {
+	t.Helper()
+
+	var notices []string
+	app := cli.NewApp("update-test", version).WithUpdateCheck(cli.UpdateCheckConfig{
+		RepoOwner: "acme",
+		RepoName:  "tool",
+		BaseURL:   uc.server.URL,
+		Notifier: func(current, latest string) {
+			notices = append(notices, current+" -> "+latest)
+		},
+	})
+	app.AddCommand(cli.Command("run", "Run", noop))
+	app.Root().SetOut(&bytes.Buffer{})
+	if err := app.RunWithArgs(append([]string{"run"}, args...)); err != nil {
+		t.Fatalf("RunWithArgs: %v", err)
+	}
+	return notices
+}
+
+func isolateUpdateState(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("AppData", dir)
+	t.Setenv("CI", "")
+}
+
+func TestWithUpdateCheck_NotifiesNewerRelease(t *testing.T) {
+	isolateUpdateState(t)
+	uc := newReleaseServer(t, "v1.2.4")
+
+	notices := runUpdateApp(t, uc, "1.2.3")
+	if len(notices) != 1 || notices[0] != "v1.2.3 -> v1.2.4" {
+		t.Fatalf("notices = %v, want one v1.2.3 -> v1.2.4", notices)
+	}
+
+	// The recorded check time suppresses another request within Interval.
+	if notices := runUpdateApp(t, uc, "1.2.3"); len(notices) != 0 {
+		t.Errorf("second run notices = %v, want none", notices)
+	}
+	if got := uc.requests.Load(); got != 1 {
+		t.Errorf("release requests = %d, want 1", got)
+	}
+}
+
+func TestWithUpdateCheck_Skips(t *testing.T) {
+	tests := []struct {
+		name    string
+		tag     string
+		version string
+		ci      string
+		args    []string
+		request bool
+	}{
+		{name: "current release", tag: "v1.2.3", version: "1.2.3", request: true},
+		{name: "older release", tag: "v1.2.10", version: "v1.10.0", request: true},
+		{name: "pre-release of current", tag: "v1.2.0-rc.2", version: "1.2.0", request: true},
+		{name: "ci", tag: "v9.0.0", version: "1.2.3", ci: "true"},
+		{name: "flag", tag: "v9.0.0", version: "1.2.3", args: []string{"--no-update-check"}},
+		{name: "dev build", tag: "v9.0.0", version: "dev"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateUpdateState(t)
+			t.Setenv("CI", tt.ci)
+			uc := newReleaseServer(t, tt.tag)
+
+			if notices := runUpdateApp(t, uc, tt.version, tt.args...); len(notices) != 0 {
+				t.Errorf("notices = %v, want none", notices)
+			}
+			if requested := uc.requests.Load() > 0; requested != tt.request {
+				t.Errorf("requested = %v, want %v", requested, tt.request)
+			}
+		})
+	}
+}
+
+func TestWithUpdateCheck_DefaultNotice(t *testing.T) {
+	isolateUpdateState(t)
+	uc := newReleaseServer(t, "2.0.0")
+
+	var stderr bytes.Buffer
+	app := cli.NewApp("mytool", "1.9.9").WithUpdateCheck(cli.UpdateCheckConfig{
+		RepoOwner: "acme",
+		RepoName:  "tool",
+		BaseURL:   uc.server.URL,
+	})
+	app.AddCommand(cli.Command("run", "Run", noop))
+	app.Root().SetErr(&stderr)
+	if err := app.RunWithArgs([]string{"run"}); err != nil {
+		t.Fatalf("RunWithArgs: %v", err)
+	}
+	if !strings.Contains(stderr.String(), "Update available: v1.9.9 → v2.0.0") || strings.Contains(stderr.String(), "mytool update") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestWithUpdateCheck_RecordsFailedCheck(t *testing.T) {
+	isolateUpdateState(t)
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+	uc := &updateCheck{server: server}
+
+	for range 2 {
+		if notices := runUpdateApp(t, uc, "1.2.3"); len(notices) != 0 {
+			t.Fatalf("notices = %v, want none", notices)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("release requests = %d, want 1: a failed check must still wait for Interval", got)
+	}
+}
+
+func TestWithUpdateCheck_KeepsPostRunHooks(t *testing.T) {
+	tests := []struct {
+		name       string
+		ownHook    bool
+		wantHooked string
+	}{
+		{name: "root hook", wantHooked: "root"},
+		{name: "subcommand hook", ownHook: true, wantHooked: "run"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isolateUpdateState(t)
+			uc := newReleaseServer(t, "v1.2.4")
+
+			var notices, hooks []string
+			app := cli.NewApp("update-test", "1.2.3")
+			app.Root().PersistentPostRun = func(*cobra.Command, []string) { hooks = append(hooks, "root") }
+			app.WithUpdateCheck(cli.UpdateCheckConfig{
+				RepoOwner: "acme",
+				RepoName:  "tool",
+				BaseURL:   uc.server.URL,
+				Notifier:  func(current, latest string) { notices = append(notices, latest) },
+			})
+			run := cli.Command("run", "Run", noop)
+			if tt.ownHook {
+				run.PersistentPostRun = func(*cobra.Command, []string) { hooks = append(hooks, "run") }
+			}
+			app.AddCommand(run)
+			if err := app.RunWithArgs([]string{"run"}); err != nil {
+				t.Fatalf("RunWithArgs: %v", err)
+			}
+			if len(notices) != 1 {
+				t.Errorf("notices = %v, want one", notices)
+			}
+			if len(hooks) != 1 || hooks[0] != tt.wantHooked {
+				t.Errorf("hooks = %v, want [%s]", hooks, tt.wantHooked)
+			}
+		})
+	}
+}
+
+func TestWithUpdateCheck_PreReleaseUpgrades(t *testing.T) {
+	tests := []struct {
+		tag, version string
+	}{
+		{tag: "v1.2.0", version: "1.2.0-rc1"},
+		{tag: "v1.2.0-rc.10", version: "1.2.0-rc.9"},
+		{tag: "v1.2.0-rc.1", version: "1.2.0-beta.2"},
+		{tag: "v1.2.0-alpha.1", version: "1.2.0-alpha"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			isolateUpdateState(t)
+			uc := newReleaseServer(t, tt.tag)
+
+			if notices := runUpdateApp(t, uc, tt.version); len(notices) != 1 {
+				t.Errorf("notices = %v, want one for %s", notices, tt.tag)
+			}
+		})
+	}
+}
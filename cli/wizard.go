@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Wizard runs named steps in order, numbering each one, and offers to retry
+// a step that fails.
+type Wizard struct {
+	title    string
+	steps    []wizardStep
+	prompter *Prompter
+}
+
+type wizardStep struct {
+	name string
+	fn   func(*WizardContext) error
+}
+
+// WizardContext is passed to each wizard step. It asks questions through
+// the wizard's prompter and carries values between steps.
+type WizardContext struct {
+	prompter *Prompter
+	values   map[string]string
+}
+
+// NewWizard creates a wizard that prompts on stdin and stdout.
+//
+// Example:
+//
+//	err := cli.NewWizard("Set up myapp").
+//	    AddStep("Database", func(ctx *cli.WizardContext) error {
+//	        dsn, err := ctx.Ask("Database URL")
+//	        ctx.Set("dsn", dsn)
+//	        return err
+//	    }).
+//	    AddStep("Confirm", func(ctx *cli.WizardContext) error {
+//	        return writeConfig(ctx.Get("dsn"))
+//	    }).
+//	    Run()
+func NewWizard(title string) *Wizard {
+	return &Wizard{title: title, prompter: NewPrompter()}
+}
+
+// WithPrompter asks questions through p, such as PrompterFor(cmd).
+func (w *Wizard) WithPrompter(p *Prompter) *Wizard {
+	w.prompter = p
+	return w
+}
+
+// SetReader sets the input reader.
+func (w *Wizard) SetReader(r io.Reader) *Wizard {
+	w.prompter.SetReader(r)
+	return w
+}
+
+// SetWriter sets the output writer.
+func (w *Wizard) SetWriter(out io.Writer) *Wizard {
+	w.prompter.SetWriter(out)
+	return w
+}
+
+// AddStep appends a step.
+func (w *Wizard) AddStep(name string, fn func(ctx *WizardContext) error) *Wizard {
+	w.steps = append(w.steps, wizardStep{name: name, fn: fn})
+	return w
+}
+
+// Run executes the steps in order. When a step fails, the user is asked
+// whether to retry it; declining aborts the wizard with the step's error.
+// Non-interactive prompters abort on the first failure.
+func (w *Wizard) Run() error {
+	out := w.prompter.writer
+	ctx := &WizardContext{prompter: w.prompter, values: make(map[string]string)}
+
+	if w.title != "" {
+		fmt.Fprintln(out, styleBold.Render(w.title))
+	}
+	for i, step := range w.steps {
+		for {
+			fmt.Fprintln(out, styleHeading.Render(fmt.Sprintf("Step %d of %d: %s", i+1, len(w.steps), step.name)))
+			err := step.fn(ctx)
+			if err == nil {
+				break
+			}
+			fmt.Fprintln(out, styleError.Render("✗ "+err.Error()))
+			if w.prompter.assumeYes || w.prompter.nonInteractive {
+				return fmt.Errorf("%s: %w", step.name, err)
+			}
+			retry, promptErr := w.prompter.Confirm("Retry this step?")
+			if promptErr != nil || !retry {
+				return fmt.Errorf("%s: %w", step.name, errors.Join(err, promptErr))
+			}
+		}
+	}
+	return nil
+}
+
+// Ask reads a free-form answer.
+func (c *WizardContext) Ask(prompt string) (string, error) {
+	return c.prompter.Ask(prompt)
+}
+
+// Confirm asks a yes/no question that defaults to no.
+func (c *WizardContext) Confirm(prompt string) (bool, error) {
+	return c.prompter.Confirm(prompt)
+}
+
+// Select asks for one of options.
+func (c *WizardContext) Select(prompt string, options []string) (string, error) {
+	return c.prompter.SingleSelect(prompt, options)
+}
+
+// Set stores a value for later steps.
+func (c *WizardContext) Set(key, value string) {
+	c.values[key] = value
+}
+
+// Get returns a value stored by an earlier step, or "".
+func (c *WizardContext) Get(key string) string {
+	return c.values[key]
+}
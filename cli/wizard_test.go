@@ -0,0 +1,88 @@
+package cli_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/cli"
+)
+
+func TestWizard_Run(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	attempts := 0
+	var summary string
+	err := cli.NewWizard("Set up myapp").
+		SetReader(strings.NewReader("postgres://db\ny\n2\ny\n")).
+		SetWriter(&out).
+		AddStep("Database setup", func(ctx *cli.WizardContext) error {
+			dsn, err := ctx.Ask("Database URL")
+			ctx.Set("dsn", dsn)
+			return err
+		}).
+		AddStep("Environment", func(ctx *cli.WizardContext) error {
+			attempts++
+			if attempts == 1 {
+				return errors.New("registry unreachable")
+			}
+			env, err := ctx.Select("Environment", []string{"dev", "prod"})
+			ctx.Set("env", env)
+			return err
+		}).
+		AddStep("Review", func(ctx *cli.WizardContext) error {
+			ok, err := ctx.Confirm("Write config?")
+			if ok {
+				summary = ctx.Get("dsn") + " " + ctx.Get("env")
+			}
+			return err
+		}).
+		Run()
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if summary != "postgres://db prod" {
+		t.Errorf("summary = %q", summary)
+	}
+
+	last := -1
+	for _, want := range []string{
+		"Set up myapp",
+		"Step 1 of 3: Database setup",
+		"Database URL: ",
+		"Step 2 of 3: Environment",
+		"✗ registry unreachable",
+		"Retry this step? [y/N]",
+		"Step 2 of 3: Environment",
+		"2. [ ] prod",
+		"Step 3 of 3: Review",
+		"Write config? [y/N]",
+	} {
+		i := strings.Index(out.String()[last+1:], want)
+		if i < 0 {
+			t.Fatalf("output missing %q after offset %d:\n%s", want, last, out.String())
+		}
+		last += 1 + i
+	}
+}
+
+func TestWizard_AbortOnDeclinedRetry(t *testing.T) {
+	t.Parallel()
+
+	stepErr := errors.New("disk full")
+	ran := false
+	err := cli.NewWizard("").
+		SetReader(strings.NewReader("n\n")).
+		SetWriter(&bytes.Buffer{}).
+		AddStep("Write files", func(*cli.WizardContext) error { return stepErr }).
+		AddStep("Never", func(*cli.WizardContext) error { ran = true; return nil }).
+		Run()
+	if !errors.Is(err, stepErr) || !strings.Contains(err.Error(), "Write files") {
+		t.Errorf("err = %v, want wrapped step error", err)
+	}
+	if ran {
+		t.Error("step after abort ran")
+	}
+}
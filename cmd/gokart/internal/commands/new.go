@@ -14,6 +14,9 @@ type newCommand struct {
 	Structured    bool          `help:"Use a managed cmd/ and internal/ layout for later integrations." group:"Project"`
 	Module        string        `help:"Go module path." group:"Project"`
 	Example       bool          `help:"Include a runnable greet command and tests." group:"Project"`
+	Docker        bool          `help:"Include a multi-stage Dockerfile and .dockerignore." group:"Project"`
+	GitHubActions bool          `name:"github-actions" help:"Include a GitHub Actions CI workflow." group:"Project"`
+	Air           bool          `help:"Include an air live-reload config." group:"Project"`
 	DB            string        `default:"none" enum:"sqlite,postgres,none" help:"Database backend." group:"Integrations / Configuration"`
 	AI            bool          `help:"Include OpenAI client." group:"Integrations / Configuration"`
 	Redis         bool          `help:"Include Redis cache." group:"Integrations / Configuration"`
@@ -41,6 +44,7 @@ func (c *newCommand) Run() error {
 	req := generator.CreateRequest{
 		Args: c.Args, Flat: c.Flat, Structured: c.Structured, Module: c.Module,
 		DB: c.DB, AI: c.AI, Redis: c.Redis, Example: c.Example, Local: c.Local,
+		Docker: c.Docker, GitHubActions: c.GitHubActions, Air: c.Air,
 		Global: c.Global, ConfigScope: c.ConfigScope, DryRun: c.DryRun, Force: c.Force,
 		SkipExisting: c.SkipExisting, NoManifest: c.NoManifest, Verify: c.Verify,
 		NoVerify: c.NoVerify, VerifyOnly: c.VerifyOnly, VerifyTimeout: c.VerifyTimeout,
@@ -29,6 +29,9 @@ const (
 	newFlagAI              = "ai"
 	newFlagRedis           = "redis"
 	newFlagExample         = "example"
+	newFlagDocker          = "docker"
+	newFlagGitHubActions   = "github-actions"
+	newFlagAir             = "air"
 	newFlagLocal           = "local"
 	newFlagGlobal          = "global"
 	newFlagConfigScope     = "config-scope"
@@ -48,7 +51,7 @@ var (
 	projectNamePattern         = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
 	moduleSegmentPattern       = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
 	verifyOnlyIgnoredFlagNames = []string{
-		newFlagFlat, newFlagStructured, newFlagModule, newFlagDB, newFlagAI, newFlagRedis, newFlagExample,
+		newFlagFlat, newFlagStructured, newFlagModule, newFlagDB, newFlagAI, newFlagRedis, newFlagExample, newFlagDocker, newFlagGitHubActions, newFlagAir,
 		newFlagLocal, newFlagGlobal, newFlagConfigScope, newFlagForce, newFlagSkipExisting, newFlagNoManifest,
 	}
 )
@@ -165,6 +168,9 @@ type CreateRequest struct {
 	AI            bool
 	Redis         bool
 	Example       bool
+	Docker        bool
+	GitHubActions bool
+	Air           bool
 	Local         bool
 	Global        bool
 	ConfigScope   string
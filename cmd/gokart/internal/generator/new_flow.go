@@ -112,11 +112,21 @@ func newApplyOptions(req newRequest) ApplyOptions {
 
 func scaffoldProject(req newRequest, opts ApplyOptions, version string) (*ApplyResult, error) {
 	opts.GeneratorVersion = version
+	var extras []ScaffoldOption
+	if req.UseDocker {
+		extras = append(extras, WithDocker(defaultDockerPort))
+	}
+	if req.UseGitHubActions {
+		extras = append(extras, WithGitHubActions(""))
+	}
+	if req.UseAir {
+		extras = append(extras, WithAir())
+	}
 	switch req.Mode {
 	case modeFlat:
-		return ScaffoldFlat(req.TargetDir, req.ProjectName, req.Module, req.UseGlobal, req.IncludeExample, opts)
+		return ScaffoldFlat(req.TargetDir, req.ProjectName, req.Module, req.UseGlobal, req.IncludeExample, opts, extras...)
 	case modeStructured:
-		return ScaffoldStructured(req.TargetDir, req.ProjectName, req.Module, req.UseSQLite, req.UsePostgres, req.UseAI, req.UseRedis, req.UseGlobal, req.IncludeExample, opts)
+		return ScaffoldStructured(req.TargetDir, req.ProjectName, req.Module, req.UseSQLite, req.UsePostgres, req.UseAI, req.UseRedis, req.UseGlobal, req.IncludeExample, opts, extras...)
 	default:
 		return nil, fmt.Errorf("unsupported mode %q", req.Mode)
 	}
@@ -22,6 +22,9 @@ type newRequest struct {
 	UseAI              bool
 	UseRedis           bool
 	IncludeExample     bool
+	UseDocker          bool
+	UseGitHubActions   bool
+	UseAir             bool
 	UseGlobal          bool
 	DryRun             bool
 	WriteManifest      bool
@@ -82,6 +85,7 @@ func newRequestFromCommand(cmd *CreateRequest, preset string) (newRequest, error
 	req := newRequest{
 		Preset: preset, UseSQLite: useSQLite, UsePostgres: usePostgres, UseAI: cmd.AI,
 		UseRedis: cmd.Redis, IncludeExample: cmd.Example, ConfigScope: cmd.ConfigScope,
+		UseDocker: cmd.Docker, UseGitHubActions: cmd.GitHubActions, UseAir: cmd.Air,
 		DryRun: cmd.DryRun, Verify: cmd.Verify, VerifyOnly: cmd.VerifyOnly,
 		VerifyTimeout: cmd.VerifyTimeout,
 	}
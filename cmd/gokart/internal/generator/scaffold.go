@@ -20,6 +20,7 @@ const (
 	defaultRedisVersion  = "v9.21.0"
 	defaultGooseVersion  = "v3.27.2"
 	generatedGoVersion   = "1.26.0"
+	defaultDockerPort    = 8080
 )
 
 // TemplateData holds variables for template substitution.
@@ -27,10 +28,15 @@ type TemplateData struct {
 	Name                string
 	Module              string
 	GoVersion           string
+	GoVersionConstraint string
 	UseSQLite           bool
 	UsePostgres         bool
 	UseAI               bool
 	UseRedis            bool
+	UseDocker           bool
+	DockerPort          int
+	UseGitHubActions    bool
+	UseAir              bool
 	IncludeExample      bool
 	UseGlobal           bool
 	HasIntegrations     bool
@@ -50,6 +56,39 @@ type TemplateData struct {
 	GooseVersion          string
 }
 
+// ScaffoldOption adjusts optional project files for ScaffoldFlat and
+// ScaffoldStructured.
+type ScaffoldOption func(*TemplateData)
+
+// WithDocker adds a multi-stage Dockerfile and .dockerignore. A port of zero
+// exposes the default 8080.
+func WithDocker(port int) ScaffoldOption {
+	return func(d *TemplateData) {
+		d.UseDocker = true
+		if port > 0 {
+			d.DockerPort = port
+		}
+	}
+}
+
+// WithGitHubActions adds a .github/workflows/ci.yml that vets, tests, and
+// lints on push and pull request. goVersion is the setup-go version
+// constraint, such as "1.26.x"; empty uses the generated go.mod version.
+func WithGitHubActions(goVersion string) ScaffoldOption {
+	return func(d *TemplateData) {
+		d.UseGitHubActions = true
+		if goVersion != "" {
+			d.GoVersionConstraint = goVersion
+		}
+	}
+}
+
+// WithAir adds an .air.toml live-reload config. Projects with a database
+// load .env before each restart.
+func WithAir() ScaffoldOption {
+	return func(d *TemplateData) { d.UseAir = true }
+}
+
 type scaffoldSpec struct {
 	Dir          string
 	TemplateRoot string
@@ -60,9 +99,10 @@ type scaffoldSpec struct {
 // ScaffoldFlat creates a flat project structure with a single main.go.
 //
 //nolint:revive // public API, params are distinct
-func ScaffoldFlat(dir, name, module string, useGlobal, includeExample bool, opts ApplyOptions) (*ApplyResult, error) {
+func ScaffoldFlat(dir, name, module string, useGlobal, includeExample bool, opts ApplyOptions, extras ...ScaffoldOption) (*ApplyResult, error) {
 	opts.SkipManifest = true
 	data := baseTemplateData(name, module, useGlobal, includeExample)
+	data.apply(extras)
 	data.derive(false)
 	return applyScaffoldSpec(scaffoldSpec{
 		Dir:          dir,
@@ -79,8 +119,9 @@ func ScaffoldFlat(dir, name, module string, useGlobal, includeExample bool, opts
 // ScaffoldStructured creates a structured project with cmd/, internal/commands/, internal/actions/.
 //
 //nolint:revive // public API, boolean flags for each integration
-func ScaffoldStructured(dir, name, module string, useSQLite, usePostgres, useAI, useRedis, useGlobal, includeExample bool, opts ApplyOptions) (*ApplyResult, error) {
+func ScaffoldStructured(dir, name, module string, useSQLite, usePostgres, useAI, useRedis, useGlobal, includeExample bool, opts ApplyOptions, extras ...ScaffoldOption) (*ApplyResult, error) {
 	data := baseTemplateData(name, module, useGlobal, includeExample)
+	data.apply(extras)
 	data.UseSQLite = useSQLite
 	data.UsePostgres = usePostgres
 	data.UseAI = useAI
@@ -113,11 +154,13 @@ func applyScaffoldSpec(spec scaffoldSpec, opts ApplyOptions) (*ApplyResult, erro
 
 func baseTemplateData(name, module string, useGlobal, includeExample bool) TemplateData {
 	return TemplateData{
-		Name:           name,
-		Module:         module,
-		GoVersion:      generatedGoVersion,
-		IncludeExample: includeExample,
-		UseGlobal:      useGlobal,
+		Name:                name,
+		Module:              module,
+		GoVersion:           generatedGoVersion,
+		GoVersionConstraint: generatedGoVersion,
+		DockerPort:          defaultDockerPort,
+		IncludeExample:      includeExample,
+		UseGlobal:           useGlobal,
 
 		GokartSQLiteVersion:   defaultGokartSQLiteVersion,
 		GokartPostgresVersion: defaultGokartPostgresVersion,
@@ -131,6 +174,14 @@ func baseTemplateData(name, module string, useGlobal, includeExample bool) Templ
 	}
 }
 
+func (d *TemplateData) apply(extras []ScaffoldOption) {
+	for _, extra := range extras {
+		if extra != nil {
+			extra(d)
+		}
+	}
+}
+
 func (d *TemplateData) derive(managed bool) {
 	d.HasIntegrations = d.UseSQLite || d.UsePostgres || d.UseAI || d.UseRedis
 	d.HasAppPackage = d.UseGlobal || d.HasIntegrations
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 
 	"github.com/spf13/viper"
@@ -47,14 +48,62 @@ func LoadConfig[T any](paths ...string) (T, error) {
 //	}
 //	cfg, err := gokart.LoadConfigWithDefaults(defaults, "config.yaml")
 func LoadConfigWithDefaults[T any](defaults T, paths ...string) (T, error) {
+	return loadConfig(defaults, "", false, paths)
+}
+
+// LoadConfigWithPrefix loads configuration like LoadConfig, but only binds
+// environment variables that start with prefix and an underscore. Viper
+// strips the prefix before matching keys, so with prefix "MYAPP" the
+// variable MYAPP_DB_HOST sets db.host and an unprefixed DB_HOST is ignored.
+// With a non-empty prefix every key T declares is bound, so a prefixed
+// variable can set a key the config file omits; an empty prefix behaves
+// like LoadConfig.
+//
+// Example:
+//
+//	cfg, err := gokart.LoadConfigWithPrefix[Config]("MYAPP", "config.yaml")
+func LoadConfigWithPrefix[T any](prefix string, paths ...string) (T, error) {
+	var zero T
+	return loadConfig(zero, prefix, prefix != "", paths)
+}
+
+// LoadConfigFromEnv loads configuration from environment variables alone,
+// for services configured entirely by their environment. Every key T
+// declares is bound to prefix, an underscore, and the key with dots replaced
+// by underscores, so with prefix "MYAPP" the variable MYAPP_DB_HOST sets
+// db.host. An empty prefix binds the unprefixed names, so a field named
+// home or user then reads the process's HOME or USER.
+//
+// Example:
+//
+//	cfg, err := gokart.LoadConfigFromEnv[Config]("MYAPP")
+func LoadConfigFromEnv[T any](prefix string) (T, error) {
+	var zero T
+	return loadConfig(zero, prefix, true, nil)
+}
+
+func loadConfig[T any](defaults T, envPrefix string, bindAll bool, paths []string) (T, error) {
 	v := viper.New()
 
 	// Enable automatic environment variable binding
+	if envPrefix != "" {
+		v.SetEnvPrefix(envPrefix)
+	}
 	v.AutomaticEnv()
 
 	// Replace . with _ in environment variables (e.g., db.host → DB_HOST)
 	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 
+	// AutomaticEnv only answers for keys viper already knows, so bind every
+	// key T declares; otherwise env-only keys never reach Unmarshal. Only
+	// prefixed and env-only loads do this: unprefixed, a field named home or
+	// path would be overwritten by the process's HOME or PATH.
+	if bindAll {
+		if err := bindConfigEnv(v, reflect.TypeOf(defaults), "", map[reflect.Type]bool{}); err != nil {
+			return defaults, fmt.Errorf("bind config env: %w", err)
+		}
+	}
+
 	// Try each config path in order
 	var configFound bool
 	for _, path := range paths {
@@ -79,3 +128,60 @@ func LoadConfigWithDefaults[T any](defaults T, paths ...string) (T, error) {
 
 	return defaults, nil
 }
+
+// bindConfigEnv binds an environment variable for each leaf key of t, named
+// the way v.Unmarshal decodes them. path holds the struct types being walked,
+// so a self-referential type such as struct{ Next *Node } stops at the
+// repeated type instead of recursing forever.
+func bindConfigEnv(v *viper.Viper, t reflect.Type, prefix string, path map[reflect.Type]bool) error {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || t == durationType || t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
+		if prefix == "" {
+			return nil
+		}
+		return v.BindEnv(prefix)
+	}
+	if path[t] {
+		return nil
+	}
+	path[t] = true
+	defer delete(path, t)
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, squash := unmarshalFieldKey(field)
+		if key == "-" {
+			continue
+		}
+		next := joinConfigKey(prefix, key)
+		if squash {
+			next = prefix
+		}
+		if err := bindConfigEnv(v, field.Type, next, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// unmarshalFieldKey names field the way viper's decoder does: by its
+// mapstructure tag or lowercased field name. Embedded structs are nested
+// under their type name unless tagged ",squash"; config tags are ignored.
+func unmarshalFieldKey(field reflect.StructField) (string, bool) {
+	name, opts, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		name = field.Name
+	}
+	return strings.ToLower(name), strings.Contains(","+opts+",", ",squash,")
+}
+
+func joinConfigKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
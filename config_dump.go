@@ -0,0 +1,168 @@
+package gokart
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// RedactedValue replaces fields tagged `gokart:"secret"` in DumpConfig and
+// DebugConfig output.
+const RedactedValue = "[REDACTED]"
+
+// DumpConfig writes cfg to w as "json", "yaml", or "toml" so operators can
+// see the configuration a service is actually using.
+//
+// Keys follow the names LoadConfig reads, so a dump without secrets loads
+// back unchanged: the mapstructure tag, else the lowercase field name.
+// Embedded structs nest under their lowercase type name unless tagged
+// ",squash", and config tags are ignored, so the output does not match
+// ParseConfig for structs named with config tags. Durations are written as
+// strings like "5s", and fields tagged `gokart:"secret"` are written as
+// RedactedValue.
+//
+// Example:
+//
+//	type Config struct {
+//	    Host     string `mapstructure:"host"`
+//	    Password string `mapstructure:"password" gokart:"secret"`
+//	}
+//	err := gokart.DumpConfig(cfg, "yaml", os.Stdout)
+func DumpConfig(cfg any, format string, w io.Writer) error {
+	tree := configTree(reflect.ValueOf(cfg))
+	var (
+		content []byte
+		err     error
+	)
+	switch strings.ToLower(format) {
+	case "json":
+		content, err = json.MarshalIndent(tree, "", "  ")
+		if err == nil {
+			content = append(content, '\n')
+		}
+	case "yaml", "yml":
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(tree)
+		if err == nil {
+			err = encoder.Close()
+		}
+		content = buf.Bytes()
+	case "toml":
+		content, err = toml.Marshal(tree)
+	default:
+		return fmt.Errorf("dump config: unsupported format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("dump config: marshal %s: %w", format, err)
+	}
+	if _, err := w.Write(content); err != nil {
+		return fmt.Errorf("dump config: write: %w", err)
+	}
+	return nil
+}
+
+// DebugConfig logs cfg as redacted JSON with slog.Debug, for example right
+// after LoadConfig at startup.
+func DebugConfig(cfg any) {
+	content, err := json.Marshal(configTree(reflect.ValueOf(cfg)))
+	if err != nil {
+		slog.Debug("effective config", "error", err)
+		return
+	}
+	slog.Debug("effective config", "config", string(content))
+}
+
+var (
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// configTree converts v to maps, slices, and scalars keyed by config names,
+// with secret fields redacted. Nil pointers and interfaces become nil.
+func configTree(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).String()
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		if _, ok := v.Interface().(encoding.TextMarshaler); ok {
+			return v.Interface()
+		}
+		tree := make(map[string]any, v.NumField())
+		addConfigFields(tree, v)
+		return tree
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return v.Interface()
+		}
+		tree := make(map[string]any, v.Len())
+		for iter := v.MapRange(); iter.Next(); {
+			if value := configTree(iter.Value()); value != nil {
+				tree[iter.Key().String()] = value
+			}
+		}
+		return tree
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+		items := make([]any, v.Len())
+		for i := range items {
+			items[i] = configTree(v.Index(i))
+		}
+		return items
+	default:
+		return v.Interface()
+	}
+}
+
+func addConfigFields(tree map[string]any, v reflect.Value) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key, squash := unmarshalFieldKey(field)
+		if key == "-" {
+			continue
+		}
+		value := v.Field(i)
+		if squash {
+			for value.Kind() == reflect.Pointer && !value.IsNil() {
+				value = value.Elem()
+			}
+			if value.Kind() == reflect.Struct {
+				addConfigFields(tree, value)
+				continue
+			}
+		}
+		if field.Tag.Get("gokart") == "secret" {
+			tree[key] = RedactedValue
+			continue
+		}
+		// Omit nil values: TOML has no null.
+		if item := configTree(value); item != nil {
+			tree[key] = item
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package gokart_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dotcommander/gokart"
+)
+
+type dumpDBConfig struct {
+	Host     string `mapstructure:"host"`
+	Password string `mapstructure:"password" gokart:"secret"`
+}
+
+type dumpConfig struct {
+	Name    string        `mapstructure:"name"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	APIKey  string        `mapstructure:"api_key" gokart:"secret"`
+	DB      dumpDBConfig  `mapstructure:"db"`
+	Tags    []string      `mapstructure:"tags"`
+	Skipped string        `mapstructure:"-"`
+}
+
+func sampleDumpConfig() dumpConfig {
+	return dumpConfig{
+		Name:    "api",
+		Timeout: 5 * time.Second,
+		APIKey:  "sk-live-123",
+		DB:      dumpDBConfig{Host: "db.internal", Password: "hunter2"},
+		Tags:    []string{"a", "b"},
+		Skipped: "hidden",
+	}
+}
+
+func TestDumpConfig_JSONRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	require.NoError(t, gokart.DumpConfig(sampleDumpConfig(), "json", &buf))
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &got))
+	assert.Equal(t, map[string]any{
+		"name":    "api",
+		"timeout": "5s",
+		"api_key": gokart.RedactedValue,
+		"db": map[string]any{
+			"host":     "db.internal",
+			"password": gokart.RedactedValue,
+		},
+		"tags": []any{"a", "b"},
+	}, got)
+}
+
+func TestDumpConfig_YAMLAndTOMLRedactSecrets(t *testing.T) {
+	t.Parallel()
+
+	cfg := sampleDumpConfig()
+	for _, format := range []string{"yaml", "toml"} {
+		var buf bytes.Buffer
+		require.NoError(t, gokart.DumpConfig(&cfg, format, &buf), format)
+		out := buf.String()
+		assert.Contains(t, out, "db.internal", format)
+		assert.Contains(t, out, gokart.RedactedValue, format)
+		assert.NotContains(t, out, "hunter2", format)
+		assert.NotContains(t, out, "sk-live-123", format)
+		assert.NotContains(t, out, "hidden", format)
+	}
+}
+
+// DumpBaseConfig is exported so LoadConfig can set the embedded fields.
+type DumpBaseConfig struct {
+	Region string `mapstructure:"region"`
+}
+
+type dumpSquashConfig struct {
+	Zone string `mapstructure:"zone"`
+}
+
+type dumpReloadConfig struct {
+	DumpBaseConfig
+	Squashed dumpSquashConfig `mapstructure:",squash"`
+	Name     string           `mapstructure:"name"`
+	Label    string           `config:"label_name"`
+	Timeout  time.Duration    `mapstructure:"timeout"`
+	DB       dumpDBConfig     `mapstructure:"db"`
+	Tags     []string         `mapstructure:"tags"`
+}
+
+func TestDumpConfig_ReloadsThroughLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	want := dumpReloadConfig{
+		DumpBaseConfig: DumpBaseConfig{Region: "eu"},
+		Squashed:       dumpSquashConfig{Zone: "b"},
+		Name:           "api",
+		Label:          "primary",
+		Timeout:        5 * time.Second,
+		DB:             dumpDBConfig{Host: "db.internal"},
+		Tags:           []string{"a", "b"},
+	}
+	for _, format := range []string{"json", "yaml", "toml"} {
+		var buf bytes.Buffer
+		require.NoError(t, gokart.DumpConfig(want, format, &buf), format)
+		path := filepath.Join(t.TempDir(), "config."+format)
+		require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600), format)
+
+		got, err := gokart.LoadConfig[dumpReloadConfig](path)
+		require.NoError(t, err, format)
+		// Password is a secret, so the dump carries RedactedValue.
+		got.DB.Password = ""
+		assert.Equal(t, want, got, format)
+	}
+}
+
+func TestDumpConfig_RejectsUnknownFormat(t *testing.T) {
+	t.Parallel()
+
+	err := gokart.DumpConfig(sampleDumpConfig(), "xml", &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"xml"`)
+}
+
+func TestDebugConfig_LogsRedactedJSON(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	gokart.DebugConfig(sampleDumpConfig())
+
+	var record struct {
+		Level  string `json:"level"`
+		Msg    string `json:"msg"`
+		Config string `json:"config"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "DEBUG", record.Level)
+	assert.Contains(t, record.Config, `"host":"db.internal"`)
+	assert.Contains(t, record.Config, `"password":"[REDACTED]"`)
+	assert.NotContains(t, record.Config, "hunter2")
+}
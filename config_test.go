@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -126,3 +127,126 @@ func TestLoadConfigWithDefaults_UnreadableCandidateDoesNotFallThrough(t *testing
 	assert.Contains(t, err.Error(), unreadable)
 	assert.Equal(t, testConfig{Host: "default"}, got)
 }
+
+type prefixedConfig struct {
+	DB struct {
+		Host string `mapstructure:"host"`
+	} `mapstructure:"db"`
+}
+
+func TestLoadConfigWithPrefix_ReadsPrefixedEnv(t *testing.T) {
+	path := writeTempYAML(t, "db:\n  host: filehost\n")
+	t.Setenv("MYAPP_DB_HOST", "testhost")
+
+	got, err := gokart.LoadConfigWithPrefix[prefixedConfig]("MYAPP", path)
+	require.NoError(t, err)
+	assert.Equal(t, "testhost", got.DB.Host)
+}
+
+func TestLoadConfigWithPrefix_IgnoresUnprefixedEnv(t *testing.T) {
+	path := writeTempYAML(t, "db:\n  host: filehost\n")
+	t.Setenv("DB_HOST", "globalhost")
+
+	got, err := gokart.LoadConfigWithPrefix[prefixedConfig]("MYAPP", path)
+	require.NoError(t, err)
+	assert.Equal(t, "filehost", got.DB.Host)
+}
+
+type shellNamedConfig struct {
+	Host string `mapstructure:"host"`
+	Home string `mapstructure:"home"`
+	User string `mapstructure:"user"`
+}
+
+func TestLoadConfig_UnsetKeysIgnoreProcessEnv(t *testing.T) {
+	path := writeTempYAML(t, "host: filehost\n")
+	t.Setenv("HOME", "/home/someone")
+	t.Setenv("USER", "someone")
+
+	got, err := gokart.LoadConfig[shellNamedConfig](path)
+	require.NoError(t, err)
+	assert.Equal(t, "filehost", got.Host)
+	assert.Empty(t, got.Home, "HOME must not fill a key the file omits")
+	assert.Empty(t, got.User, "USER must not fill a key the file omits")
+
+	got, err = gokart.LoadConfigWithPrefix[shellNamedConfig]("", path)
+	require.NoError(t, err)
+	assert.Empty(t, got.Home)
+	assert.Empty(t, got.User)
+}
+
+type envOnlyConfig struct {
+	Name    string        `mapstructure:"name"`
+	Timeout time.Duration `mapstructure:"timeout"`
+	DB      struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	} `mapstructure:"db"`
+}
+
+func TestLoadConfigFromEnv_PopulatesNestedFields(t *testing.T) {
+	t.Setenv("MYAPP_NAME", "api")
+	t.Setenv("MYAPP_TIMEOUT", "5s")
+	t.Setenv("MYAPP_DB_HOST", "db.internal")
+	t.Setenv("MYAPP_DB_PORT", "5433")
+	t.Setenv("DB_HOST", "unprefixed")
+
+	got, err := gokart.LoadConfigFromEnv[envOnlyConfig]("MYAPP")
+	require.NoError(t, err)
+	assert.Equal(t, "api", got.Name)
+	assert.Equal(t, 5*time.Second, got.Timeout)
+	assert.Equal(t, "db.internal", got.DB.Host)
+	assert.Equal(t, 5433, got.DB.Port)
+}
+
+type EnvNestedBase struct {
+	Host string `mapstructure:"host"`
+}
+
+type EnvSquashedBase struct {
+	Port int `mapstructure:"port"`
+}
+
+type envEmbeddedConfig struct {
+	EnvNestedBase
+	EnvSquashedBase `mapstructure:",squash"`
+	Region          string `config:"aws_region"`
+}
+
+func TestLoadConfigFromEnv_FollowsUnmarshalKeys(t *testing.T) {
+	t.Setenv("MYAPP_ENVNESTEDBASE_HOST", "nested")
+	t.Setenv("MYAPP_HOST", "flattened")
+	t.Setenv("MYAPP_PORT", "6543")
+	t.Setenv("MYAPP_REGION", "eu-west-1")
+	t.Setenv("MYAPP_AWS_REGION", "config-tag")
+
+	got, err := gokart.LoadConfigFromEnv[envEmbeddedConfig]("MYAPP")
+	require.NoError(t, err)
+	assert.Equal(t, "nested", got.Host, "unsquashed embedded structs nest under their type name")
+	assert.Equal(t, 6543, got.Port, "squashed embedded structs share the parent's keys")
+	assert.Equal(t, "eu-west-1", got.Region, "config tags do not rename Unmarshal keys")
+}
+
+func TestLoadConfigFromEnv_LeavesUnsetFieldsZero(t *testing.T) {
+	t.Setenv("MYAPP_DB_HOST", "db.internal")
+
+	got, err := gokart.LoadConfigFromEnv[envOnlyConfig]("MYAPP")
+	require.NoError(t, err)
+	assert.Equal(t, "db.internal", got.DB.Host)
+	assert.Empty(t, got.Name)
+	assert.Zero(t, got.DB.Port)
+}
+
+type envNode struct {
+	Name string   `mapstructure:"name"`
+	Next *envNode `mapstructure:"next"`
+}
+
+func TestLoadConfigFromEnv_StopsAtSelfReferentialTypes(t *testing.T) {
+	t.Setenv("MYAPP_NAME", "head")
+
+	got, err := gokart.LoadConfigFromEnv[envNode]("MYAPP")
+	require.NoError(t, err)
+	assert.Equal(t, "head", got.Name)
+	assert.Nil(t, got.Next)
+}
@@ -13,8 +13,8 @@ func TestRootAPIDocCoversCurrentExports(t *testing.T) {
 	}
 	doc := string(data)
 	for _, symbol := range []string{
-		"ParseConfig", "MustParseConfig", "LoadConfig", "LoadConfigWithDefaults",
-		"ConfigDir", "EnsureConfigDir", "SaveState", "LoadState", "StatePath",
+		"ParseConfig", "MustParseConfig", "LoadConfig", "LoadConfigWithDefaults", "LoadConfigWithPrefix", "LoadConfigFromEnv", "DumpConfig", "DebugConfig", "RedactedValue",
+		"ConfigDir", "EnsureConfigDir", "SaveState", "LoadState", "StatePath", "NewStateManager", "SaveStateFormat", "LoadStateFormat", "StateFormatYAML", "SaveStateV", "LoadStateV",
 	} {
 		if !strings.Contains(doc, symbol) {
 			t.Errorf("root API doc omits %s", symbol)
@@ -22,15 +22,15 @@ func assertDocContains(t *testing.T, file string, required ...string) string {
 }
 
 func TestPostgresDocMatchesPublicSurface(t *testing.T) {
-	assertDocContains(t, "postgres.md", "postgres.Open", "OpenWithConfig", "FromEnv", "NewPool", "DSN", "Transaction", "NewPostgresIdentifier", "NewPostgresIndexIdentifier", "25", "5", "one-hour", "30-minute", "one-minute")
+	assertDocContains(t, "postgres.md", "postgres.Open", "OpenWithConfig", "FromEnv", "NewPool", "DSN", "Transaction", "NewPostgresIdentifier", "NewPostgresIndexIdentifier", "ScanJSON", "JSONValue", "QueryJSON", "25", "5", "one-hour", "30-minute", "one-minute")
 }
 
 func TestSQLiteDocMatchesPublicSurface(t *testing.T) {
-	assertDocContains(t, "sqlite.md", "pure-Go", "OpenReadOnly", "OpenImmutable", "ReadHeavyConfig", "ResolveConfig", "TransactionWithOptions", "Savepoint", "QuickCheck", "IntegrityCheck", "ForeignKeyCheck", "Inspect", "Optimize", "Vacuum", "Backup", "WALCheckpoint", "Retry", "IsBusy", "IsLocked", "IsConstraint")
+	assertDocContains(t, "sqlite.md", "pure-Go", "OpenReadOnly", "OpenImmutable", "ReadHeavyConfig", "ResolveConfig", "TransactionWithOptions", "Savepoint", "QuickCheck", "IntegrityCheck", "ForeignKeyCheck", "Inspect", "Optimize", "Vacuum", "VacuumWithResult", "AutoVacuum", "Backup", "OnlineBackup", "BackupToWriter", "ScheduleBackups", "CreateFTS5Table", "FTS5Search", "WALCheckpoint", "Retry", "IsBusy", "IsLocked", "IsConstraint")
 }
 
 func TestMigrateDocMatchesPublicSurface(t *testing.T) {
-	doc := assertDocContains(t, "migrate.md", "Dialect", "never auto-detected", "migrate.Up", "migrate.Down", "migrate.DownTo", "migrate.Reset", "MigrationStatuses", "migrate.Version", "migrate.Create", "migrate.Postgres", "migrate.SQLite", "embed.FS")
+	doc := assertDocContains(t, "migrate.md", "Dialect", "never auto-detected", "migrate.Up", "migrate.Down", "migrate.DownTo", "migrate.Reset", "MigrationStatuses", "migrate.Version", "migrate.Create", "migrate.DryRun", "DryRunResult", "UseLock", "ErrMigrationLocked", "migrate.Repeatable", "migrate.Postgres", "migrate.SQLite", "embed.FS")
 	if strings.Contains(doc, "Status prints") {
 		t.Error("migrate docs claim Status prints")
 	}
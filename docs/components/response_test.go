@@ -59,6 +59,7 @@ func verifyResponseFunctionsListed(t *testing.T, doc string) {
 		"func JSONStatusE(w http.ResponseWriter, status int, data any) error",
 		"func Error(w http.ResponseWriter, status int, message string)",
 		"func NoContent(w http.ResponseWriter)",
+		"func Problem(w http.ResponseWriter, p ProblemDetail)",
 	}
 	for _, fn := range requiredFunctions {
 		if !strings.Contains(doc, fn) {
@@ -75,6 +76,7 @@ func verifyResponseFunctionsListed(t *testing.T, doc string) {
 		{"| `JSONStatusE`", "Reference section missing JSONStatusE function"},
 		{"| `Error`", "Reference section missing Error function"},
 		{"| `NoContent`", "Reference section missing NoContent function"},
+		{"| `Problem`", "Reference section missing Problem function"},
 	}
 	for _, row := range referenceRows {
 		if !strings.Contains(doc, row.marker) {
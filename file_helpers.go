@@ -97,14 +97,6 @@ func readOrCreateFile(path string, defaultContent []byte, perm os.FileMode) ([]b
 	return defaultContent, nil
 }
 
-func syncDirectory(path string) error {
-	dir, err := os.Open(path)
-	if err != nil {
-		return err
-	}
-	return errors.Join(dir.Sync(), dir.Close())
-}
-
 func closeTemporaryFile(file *os.File, operationErr error) error {
 	if err := file.Close(); err != nil {
 		return errors.Join(operationErr, fmt.Errorf("close temporary file: %w", err))
@@ -0,0 +1,11 @@
+//go:build windows
+
+package gokart
+
+// syncDirectory is a no-op on Windows, which cannot flush a directory handle.
+// os.Rename uses MoveFileEx with MOVEFILE_REPLACE_EXISTING, so readers still
+// see the old or the new file, but a rename completed just before a power
+// loss may be lost.
+func syncDirectory(string) error {
+	return nil
+}
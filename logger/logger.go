@@ -2,10 +2,13 @@
 package logger
 
 import (
+	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 )
 
@@ -14,6 +17,29 @@ type Config struct {
 	Level  string    // debug, info, warn, error (default: info)
 	Format string    // json, text (default: json)
 	Output io.Writer // default: os.Stderr
+
+	// Handler, when set, is used as-is: Format and Output are ignored and the
+	// handler applies its own level filtering. Use it to plug in third-party
+	// handlers such as colorized terminal output.
+	Handler slog.Handler
+
+	// ServiceAttrs are attached to every record under a "service" group,
+	// for example {"name": "api", "version": "1.2.3", "env": "prod"}.
+	ServiceAttrs map[string]string
+}
+
+// WithAttr returns a copy of cfg with key set in ServiceAttrs. Values are
+// formatted with fmt.Sprint.
+//
+// Example:
+//
+//	cfg := logger.Config{}.WithAttr("name", "api").WithAttr("version", version)
+func (cfg Config) WithAttr(key string, value any) Config {
+	attrs := make(map[string]string, len(cfg.ServiceAttrs)+1)
+	maps.Copy(attrs, cfg.ServiceAttrs)
+	attrs[key] = fmt.Sprint(value)
+	cfg.ServiceAttrs = attrs
+	return cfg
 }
 
 // New creates a new structured logger with sensible defaults.
@@ -31,6 +57,10 @@ type Config struct {
 //	})
 //	log.Info("server started", "port", 8080)
 func New(cfg Config) *slog.Logger {
+	if cfg.Handler != nil {
+		return withServiceAttrs(slog.New(cfg.Handler), cfg.ServiceAttrs)
+	}
+
 	level := parseLevel(cfg.Level)
 
 	output := cfg.Output
@@ -50,7 +80,18 @@ func New(cfg Config) *slog.Logger {
 		handler = slog.NewJSONHandler(output, opts)
 	}
 
-	return slog.New(handler)
+	return withServiceAttrs(slog.New(handler), cfg.ServiceAttrs)
+}
+
+func withServiceAttrs(log *slog.Logger, attrs map[string]string) *slog.Logger {
+	if len(attrs) == 0 {
+		return log
+	}
+	group := make([]any, 0, len(attrs))
+	for _, key := range slices.Sorted(maps.Keys(attrs)) {
+		group = append(group, slog.String(key, attrs[key]))
+	}
+	return log.With(slog.Group("service", group...))
 }
 
 // NewDefault creates a logger with default settings (info level, JSON format, stderr).
@@ -2,7 +2,10 @@ package logger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"log/slog"
+	"maps"
 	"os"
 	"path/filepath"
 	"strings"
@@ -139,6 +142,70 @@ func TestNew_NilOutputDoesNotPanic(t *testing.T) {
 	// stderr during the test run. Construction success is the assertion.
 }
 
+// recordingHandler captures every record it handles.
+type recordingHandler struct {
+	records *[]slog.Record
+}
+
+func (h recordingHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h recordingHandler) Handle(_ context.Context, r slog.Record) error {
+	*h.records = append(*h.records, r)
+	return nil
+}
+
+func (h recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestNew_CustomHandler(t *testing.T) {
+	t.Parallel()
+	var records []slog.Record
+	var buf bytes.Buffer
+	log := New(Config{Format: "text", Output: &buf, Handler: recordingHandler{records: &records}})
+
+	log.Info("test")
+
+	if len(records) != 1 || records[0].Message != "test" {
+		t.Fatalf("records = %v, want one record with message %q", records, "test")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Output written despite custom Handler: %q", buf.String())
+	}
+}
+
+func TestNew_ServiceAttrs(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	log := New(Config{Output: &buf, ServiceAttrs: map[string]string{"version": "1.2.3"}})
+
+	log.Info("hello")
+
+	if !strings.Contains(buf.String(), `"service":{"version":"1.2.3"}`) {
+		t.Errorf("output missing service group: %q", buf.String())
+	}
+}
+
+func TestConfig_WithAttrCopiesServiceAttrs(t *testing.T) {
+	t.Parallel()
+	base := Config{ServiceAttrs: map[string]string{"name": "api"}}
+	cfg := base.WithAttr("port", 8080).WithAttr("env", "prod")
+
+	want := map[string]string{"name": "api", "port": "8080", "env": "prod"}
+	if !maps.Equal(cfg.ServiceAttrs, want) {
+		t.Errorf("ServiceAttrs = %v, want %v", cfg.ServiceAttrs, want)
+	}
+	if len(base.ServiceAttrs) != 1 {
+		t.Errorf("WithAttr mutated the original config: %v", base.ServiceAttrs)
+	}
+
+	var buf bytes.Buffer
+	cfg.Output = &buf
+	New(cfg).Info("hello")
+	if !strings.Contains(buf.String(), `"service":{"env":"prod","name":"api","port":"8080"}`) {
+		t.Errorf("output missing sorted service group: %q", buf.String())
+	}
+}
+
 func TestNewDefault_NonNil(t *testing.T) {
 	t.Parallel()
 	log := NewDefault()
@@ -0,0 +1,136 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pressly/goose/v3"
+)
+
+const (
+	directionUp   = "up"
+	directionDown = "down"
+)
+
+// DryRunMigration is one migration a command would run. Direction is "up" or
+// "down". SQL is the matching section of the migration file without goose
+// annotations; it is empty for Go migrations.
+type DryRunMigration struct {
+	Version   int64
+	Name      string
+	Direction string
+	SQL       string
+}
+
+// DryRunResult is returned as the error from Up, Down, DownTo, and Reset when
+// Config.DryRun is set. Nothing has been applied.
+//
+// Example:
+//
+//	var plan *migrate.DryRunResult
+//	if err := migrate.Up(ctx, db, cfg); errors.As(err, &plan) {
+//	    for _, m := range plan.Migrations {
+//	        fmt.Printf("-- %s (%s)\n%s\n", m.Name, m.Direction, m.SQL)
+//	    }
+//	}
+type DryRunResult struct {
+	Migrations []DryRunMigration
+}
+
+func (r *DryRunResult) Error() string {
+	return fmt.Sprintf("dry run: %d migration(s) not applied", len(r.Migrations))
+}
+
+// DryRun returns the pending migrations Up would apply, with their SQL,
+// without changing the database.
+func DryRun(ctx context.Context, db *sql.DB, cfg Config) ([]DryRunMigration, error) {
+	provider, err := newGooseProvider(cfg, db)
+	if err != nil {
+		return nil, fmt.Errorf("dry run failed: %w", err)
+	}
+	migrations, err := planMigrations(ctx, provider, cfg, directionUp, 0)
+	if err != nil {
+		return nil, fmt.Errorf("dry run failed: %w", err)
+	}
+	return migrations, nil
+}
+
+func dryRunResult(migrations []DryRunMigration, err error) error {
+	if err != nil {
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+	return &DryRunResult{Migrations: migrations}
+}
+
+// planMigrations lists the migrations a command would run. Up plans every
+// pending migration. Down plans applied migrations above target, newest
+// first; a negative target plans only the newest.
+func planMigrations(ctx context.Context, provider *goose.Provider, cfg Config, direction string, target int64) ([]DryRunMigration, error) {
+	statuses, err := provider.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+	fsys, err := migrationFS(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var selected []*goose.MigrationStatus
+	for _, status := range statuses {
+		switch {
+		case direction == directionUp && status.State == goose.StatePending:
+			selected = append(selected, status)
+		case direction == directionDown && status.State == goose.StateApplied && status.Source.Version > target:
+			selected = append(selected, status)
+		}
+	}
+	if direction == directionDown {
+		slices.Reverse(selected)
+		if target < 0 && len(selected) > 1 {
+			selected = selected[:1]
+		}
+	}
+
+	migrations := make([]DryRunMigration, 0, len(selected))
+	for _, status := range selected {
+		migration := DryRunMigration{
+			Version:   status.Source.Version,
+			Name:      filepath.Base(status.Source.Path),
+			Direction: direction,
+		}
+		if status.Source.Type == goose.TypeSQL {
+			content, err := fs.ReadFile(fsys, status.Source.Path)
+			if err != nil {
+				return nil, fmt.Errorf("read migration %s: %w", migration.Name, err)
+			}
+			migration.SQL = migrationSQL(string(content), direction)
+		}
+		migrations = append(migrations, migration)
+	}
+	return migrations, nil
+}
+
+// migrationSQL returns the statements in the Up or Down section of a goose
+// SQL file with annotation lines removed.
+func migrationSQL(content, direction string) string {
+	var b strings.Builder
+	section := ""
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if annotation, ok := strings.CutPrefix(strings.TrimSpace(line), "-- +goose"); ok {
+			switch strings.ToLower(strings.TrimSpace(annotation)) {
+			case directionUp, directionDown:
+				section = strings.ToLower(strings.TrimSpace(annotation))
+			}
+			continue
+		}
+		if section == direction {
+			b.WriteString(line)
+		}
+	}
+	return strings.TrimSpace(b.String())
+}
@@ -0,0 +1,87 @@
+package migrate
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestDryRunReturnsPendingSQLWithoutApplying(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	migrationDir := writeTestMigrations(t)
+	db := openSQLiteForMigrationTest(t, filepath.Join(t.TempDir(), "dry-run.db"))
+	cfg := Config{Dir: migrationDir, Dialect: "sqlite3"}
+
+	plan, err := DryRun(ctx, db, cfg)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	want := []DryRunMigration{
+		{Version: 1, Name: "00001_init.sql", Direction: "up", SQL: "CREATE TABLE t1 (id INTEGER PRIMARY KEY);"},
+		{Version: 2, Name: "00002_more.sql", Direction: "up", SQL: "CREATE TABLE t2 (id INTEGER PRIMARY KEY);"},
+	}
+	assertPlan(t, plan, want)
+	assertTableExists(t, ctx, db, "t1", false)
+
+	dryCfg := cfg
+	dryCfg.DryRun = true
+	var result *DryRunResult
+	if err := Up(ctx, db, dryCfg); !errors.As(err, &result) {
+		t.Fatalf("dry-run Up error = %v, want *DryRunResult", err)
+	}
+	assertPlan(t, result.Migrations, want)
+	assertTableExists(t, ctx, db, "t1", false)
+
+	if err := Up(ctx, db, cfg); err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if err := Down(ctx, db, dryCfg); !errors.As(err, &result) {
+		t.Fatalf("dry-run Down error = %v, want *DryRunResult", err)
+	}
+	assertPlan(t, result.Migrations, []DryRunMigration{
+		{Version: 2, Name: "00002_more.sql", Direction: "down", SQL: "DROP TABLE t2;"},
+	})
+	if err := Reset(ctx, db, dryCfg); !errors.As(err, &result) {
+		t.Fatalf("dry-run Reset error = %v, want *DryRunResult", err)
+	}
+	if len(result.Migrations) != 2 || result.Migrations[0].Version != 2 || result.Migrations[1].Version != 1 {
+		t.Fatalf("reset plan = %#v, want versions 2 then 1", result.Migrations)
+	}
+	assertMigrationVersion(t, ctx, db, cfg, 2)
+}
+
+func TestMigrationSQLStripsAnnotations(t *testing.T) {
+	t.Parallel()
+
+	content := `-- +goose Up
+-- +goose StatementBegin
+CREATE TRIGGER touch AFTER UPDATE ON t BEGIN
+  UPDATE t SET n = n + 1;
+END;
+-- +goose StatementEnd
+-- +goose Down
+DROP TRIGGER touch;
+`
+	if got, want := migrationSQL(content, "up"), "CREATE TRIGGER touch AFTER UPDATE ON t BEGIN\n  UPDATE t SET n = n + 1;\nEND;"; got != want {
+		t.Fatalf("up SQL = %q, want %q", got, want)
+	}
+	if got := migrationSQL(content, "down"); got != "DROP TRIGGER touch;" {
+		t.Fatalf("down SQL = %q", got)
+	}
+}
+
+func assertPlan(t *testing.T, got, want []DryRunMigration) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("plan = %#v, want %#v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("plan[%d] = %#v, want %#v", i, got[i], want[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"time"
+
+	"github.com/pressly/goose/v3"
+	"github.com/pressly/goose/v3/lock"
+)
+
+// ErrMigrationLocked is returned when Config.UseLock is set and another
+// migration run holds the lock for longer than Config.LockTimeout.
+var ErrMigrationLocked = errors.New("migration lock held by another process")
+
+// newSessionLocker returns goose's PostgreSQL advisory session locker for
+// cfg, or nil when cfg.UseLock is unset. Other dialects are rejected: SQLite
+// already serializes writers, and a lock row would outlive a crashed run.
+func newSessionLocker(cfg Config) (lock.SessionLocker, error) {
+	if !cfg.UseLock {
+		return nil, nil
+	}
+	switch cfg.Dialect {
+	case "postgres", "pgx":
+	default:
+		return nil, fmt.Errorf("migration locking is not supported for dialect %q", cfg.Dialect)
+	}
+	// goose polls once a second; LockTimeout is rounded up to whole polls.
+	polls := uint64((cfg.LockTimeout + time.Second - 1) / time.Second)
+	locker, err := lock.NewPostgresSessionLocker(
+		lock.WithLockID(migrationLockID(cfg)),
+		lock.WithLockTimeout(1, max(polls, 1)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("configure migration lock: %w", err)
+	}
+	return sessionLocker{locker}, nil
+}
+
+// sessionLocker reports a lock that could not be acquired as
+// ErrMigrationLocked.
+type sessionLocker struct {
+	lock.SessionLocker
+}
+
+func (l sessionLocker) SessionLock(ctx context.Context, conn *sql.Conn) error {
+	if err := l.SessionLocker.SessionLock(ctx, conn); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("acquire migration lock: %w", ctx.Err())
+		}
+		return fmt.Errorf("%w: %w", ErrMigrationLocked, err)
+	}
+	return nil
+}
+
+// lockOption returns the goose provider option that takes the migration
+// lock, or nil when cfg.UseLock is unset. goose holds the lock on one pooled
+// connection and migrates on others, so a pool capped at one connection
+// would deadlock; it is rejected instead.
+func lockOption(cfg Config, db *sql.DB) (goose.ProviderOption, error) {
+	locker, err := newSessionLocker(cfg)
+	if err != nil || locker == nil {
+		return nil, err
+	}
+	if db.Stats().MaxOpenConnections == 1 {
+		return nil, fmt.Errorf("migration locking needs a pool of at least two connections; MaxOpenConns is 1")
+	}
+	return goose.WithSessionLocker(locker), nil
+}
+
+// migrationLockID returns cfg.LockID or a stable hash of the version table.
+func migrationLockID(cfg Config) int64 {
+	if cfg.LockID != 0 {
+		return cfg.LockID
+	}
+	h := fnv.New64a()
+	h.Write([]byte(migrationTable(cfg)))
+	return int64(h.Sum64())
+}
+
+func migrationTable(cfg Config) string {
+	if cfg.Table == "" {
+		return "goose_db_version"
+	}
+	return cfg.Table
+}
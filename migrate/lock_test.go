@@ -0,0 +1,190 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+func TestUseLockRejectsSQLite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	migrationDir := writeTestMigrations(t)
+	db := openSQLiteForMigrationTest(t, filepath.Join(t.TempDir(), "locked.db"))
+	cfg := Config{Dir: migrationDir, Dialect: "sqlite3", UseLock: true}
+
+	if err := Up(ctx, db, cfg); err == nil {
+		t.Fatal("Up with UseLock on SQLite succeeded, want an unsupported dialect error")
+	}
+	cfg.RepeatableDir = migrationDir
+	if _, err := Repeatable(ctx, db, cfg); err == nil {
+		t.Fatal("Repeatable with UseLock on SQLite succeeded, want an unsupported dialect error")
+	}
+	cfg.UseLock = false
+	if err := Up(ctx, db, cfg); err != nil {
+		t.Fatalf("Up without UseLock: %v", err)
+	}
+	assertMigrationVersion(t, ctx, db, cfg, 2)
+}
+
+type failingSessionLocker struct{}
+
+func (failingSessionLocker) SessionLock(context.Context, *sql.Conn) error {
+	return errors.New("failed to acquire lock")
+}
+
+func (failingSessionLocker) SessionUnlock(context.Context, *sql.Conn) error {
+	return nil
+}
+
+func TestSessionLockerReportsHeldLock(t *testing.T) {
+	t.Parallel()
+
+	locker := sessionLocker{failingSessionLocker{}}
+	if err := locker.SessionLock(context.Background(), nil); !errors.Is(err, ErrMigrationLocked) {
+		t.Fatalf("SessionLock error = %v, want ErrMigrationLocked", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := locker.SessionLock(ctx, nil); !errors.Is(err, context.Canceled) || errors.Is(err, ErrMigrationLocked) {
+		t.Fatalf("SessionLock after cancel error = %v, want context.Canceled", err)
+	}
+}
+
+func TestMigrationLockID(t *testing.T) {
+	t.Parallel()
+
+	if migrationLockID(Config{}) != migrationLockID(Config{Table: "goose_db_version"}) {
+		t.Fatal("default table and explicit default table hash differently")
+	}
+	if migrationLockID(Config{Table: "a"}) == migrationLockID(Config{Table: "b"}) {
+		t.Fatal("different tables share a lock ID")
+	}
+	if got := migrationLockID(Config{LockID: 42}); got != 42 {
+		t.Fatalf("explicit LockID = %d, want 42", got)
+	}
+	if locker, err := newSessionLocker(Config{Dialect: "postgres"}); locker != nil || err != nil {
+		t.Fatalf("newSessionLocker without UseLock = %v, %v; want nil, nil", locker, err)
+	}
+	if _, err := newSessionLocker(Config{Dialect: "postgres", UseLock: true}); err != nil {
+		t.Fatalf("newSessionLocker(postgres): %v", err)
+	}
+	if _, err := newSessionLocker(Config{Dialect: "mysql", UseLock: true}); err == nil {
+		t.Fatal("unsupported dialect accepted")
+	}
+}
+
+func TestLockOptionRejectsSingleConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	db := openSQLiteForMigrationTest(t, filepath.Join(t.TempDir(), "single.db"))
+	cfg := Config{Dialect: "postgres", UseLock: true}
+	db.SetMaxOpenConns(1)
+	if _, err := lockOption(cfg, db); err == nil {
+		t.Fatal("lockOption accepted a pool capped at one connection")
+	}
+	db.SetMaxOpenConns(2)
+	if opt, err := lockOption(cfg, db); opt == nil || err != nil {
+		t.Fatalf("lockOption with two connections = %v, %v", opt, err)
+	}
+	if opt, err := lockOption(Config{Dialect: "postgres"}, db); opt != nil || err != nil {
+		t.Fatalf("lockOption without UseLock = %v, %v; want nil, nil", opt, err)
+	}
+}
+
+func TestUseLockSerializesConcurrentPostgresUp(t *testing.T) {
+	url := os.Getenv("GOKART_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("set GOKART_TEST_POSTGRES_URL to run PostgreSQL integration tests")
+	}
+	ctx := t.Context()
+	openDB := func() *sql.DB {
+		db, err := sql.Open("pgx", url)
+		if err != nil {
+			t.Fatalf("open postgres db: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	// Unique names keep reruns against one database independent; the lock ID
+	// is derived from the version table.
+	suffix := time.Now().UnixNano()
+	runs := fmt.Sprintf("lock_runs_%d", suffix)
+	table := fmt.Sprintf("lock_versions_%d", suffix)
+	// The migration holds the lock for about five seconds.
+	migrations := fstest.MapFS{
+		"migrations/00001_slow.sql": {Data: []byte(fmt.Sprintf(`-- +goose Up
+CREATE TABLE %[1]s (n INTEGER);
+INSERT INTO %[1]s (n) SELECT 1 FROM pg_sleep(5);
+
+-- +goose Down
+DROP TABLE %[1]s;
+`, runs))},
+	}
+	cfg := Config{FS: migrations, Dir: "migrations", Dialect: "postgres", Table: table, UseLock: true, LockTimeout: 30 * time.Second}
+	observer := openDB()
+	t.Cleanup(func() {
+		_, _ = observer.ExecContext(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS "+runs+", "+table)
+	})
+
+	first := make(chan error, 1)
+	firstDB := openDB()
+	go func() { first <- Up(ctx, firstDB, cfg) }()
+
+	// Wait until the first run holds the advisory lock.
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var held bool
+		if err := observer.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM pg_locks WHERE locktype = 'advisory' AND granted AND database = (SELECT oid FROM pg_database WHERE datname = current_database()))").Scan(&held); err != nil {
+			t.Fatalf("query pg_locks: %v", err)
+		}
+		if held {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("first Up never took the migration lock")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	impatient := cfg
+	impatient.LockTimeout = 0
+	if err := Up(ctx, openDB(), impatient); !errors.Is(err, ErrMigrationLocked) {
+		t.Fatalf("Up while locked error = %v, want ErrMigrationLocked", err)
+	}
+
+	waitedDB := openDB()
+	started := time.Now()
+	if err := Up(ctx, waitedDB, cfg); err != nil {
+		t.Fatalf("waiting Up: %v", err)
+	}
+	if waited := time.Since(started); waited < 2*time.Second {
+		t.Fatalf("waiting Up returned after %v, before the first run released the lock", waited)
+	}
+	select {
+	case err := <-first:
+		if err != nil {
+			t.Fatalf("first Up: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("first Up did not return")
+	}
+
+	var count int
+	if err := waitedDB.QueryRowContext(ctx, "SELECT count(*) FROM "+runs).Scan(&count); err != nil {
+		t.Fatalf("count runs: %v", err)
+	}
+	if count != 1 {
+		t.Fatalf("migration ran %d times, want 1", count)
+	}
+}
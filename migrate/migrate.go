@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/pressly/goose/v3"
@@ -34,28 +35,52 @@ type Config struct {
 	// NoVersioning disables version tracking (for one-off scripts).
 	// Default: false
 	NoVersioning bool
+
+	// DryRun makes Up, Down, DownTo, and Reset return a *DryRunResult
+	// describing the migrations they would run instead of applying them.
+	// Default: false
+	DryRun bool
+
+	// RepeatableDir is the directory of repeatable .sql files applied by
+	// Repeatable, resolved like Dir.
+	RepeatableDir string
+
+	// UseLock serializes Up, Down, DownTo, Reset, and Repeatable across
+	// processes with goose's PostgreSQL advisory session lock. It supports
+	// only the postgres and pgx dialects; sqlite3, mysql, and every other
+	// dialect return an error. The lock holds one pooled connection while migrations
+	// run on another, so a pool with MaxOpenConns 1 is rejected.
+	// Default: false
+	UseLock bool
+
+	// LockID is the PostgreSQL advisory lock key.
+	// Default: a stable hash of Table
+	LockID int64
+
+	// LockTimeout is how long to wait for the lock before returning
+	// ErrMigrationLocked. The lock is retried once a second, so it is
+	// rounded up to whole seconds.
+	// Default: 0 (one retry)
+	LockTimeout time.Duration
 }
 
 func newGooseProvider(cfg Config, db *sql.DB) (*goose.Provider, error) {
-	if cfg.Dir == "" {
-		cfg.Dir = "migrations"
-	}
 	if cfg.Dialect == "" {
 		return nil, fmt.Errorf("migration dialect is required")
 	}
-
-	var fsys fs.FS
-	if cfg.FS != nil {
-		var err error
-		fsys, err = fs.Sub(cfg.FS, cfg.Dir)
-		if err != nil {
-			return nil, fmt.Errorf("open migration directory %q: %w", cfg.Dir, err)
-		}
-	} else {
-		fsys = os.DirFS(cfg.Dir)
+	fsys, err := migrationFS(cfg)
+	if err != nil {
+		return nil, err
 	}
 
-	opts := make([]goose.ProviderOption, 0, 3)
+	opts := make([]goose.ProviderOption, 0, 4)
+	lockOpt, err := lockOption(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	if lockOpt != nil {
+		opts = append(opts, lockOpt)
+	}
 	if cfg.Table != "" {
 		opts = append(opts, goose.WithTableName(cfg.Table))
 	}
@@ -73,6 +98,20 @@ func newGooseProvider(cfg Config, db *sql.DB) (*goose.Provider, error) {
 	return provider, nil
 }
 
+func migrationFS(cfg Config) (fs.FS, error) {
+	if cfg.Dir == "" {
+		cfg.Dir = "migrations"
+	}
+	if cfg.FS == nil {
+		return os.DirFS(cfg.Dir), nil
+	}
+	fsys, err := fs.Sub(cfg.FS, cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("open migration directory %q: %w", cfg.Dir, err)
+	}
+	return fsys, nil
+}
+
 // DefaultConfig returns sensible defaults.
 func DefaultConfig() Config {
 	return Config{
@@ -106,6 +145,9 @@ func Up(ctx context.Context, db *sql.DB, cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
+	if cfg.DryRun {
+		return dryRunResult(planMigrations(ctx, provider, cfg, directionUp, 0))
+	}
 	if _, err := provider.Up(ctx); err != nil {
 		return fmt.Errorf("migration failed: %w", err)
 	}
@@ -118,6 +160,9 @@ func Down(ctx context.Context, db *sql.DB, cfg Config) error {
 	if err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
+	if cfg.DryRun {
+		return dryRunResult(planMigrations(ctx, provider, cfg, directionDown, -1))
+	}
 	if _, err := provider.Down(ctx); err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
 	}
@@ -130,6 +175,9 @@ func DownTo(ctx context.Context, db *sql.DB, cfg Config, version int64) error {
 	if err != nil {
 		return fmt.Errorf("rollback to version %d failed: %w", version, err)
 	}
+	if cfg.DryRun {
+		return dryRunResult(planMigrations(ctx, provider, cfg, directionDown, version))
+	}
 	if _, err := provider.DownTo(ctx, version); err != nil {
 		return fmt.Errorf("rollback to version %d failed: %w", version, err)
 	}
@@ -142,10 +190,14 @@ func Reset(ctx context.Context, db *sql.DB, cfg Config) error {
 }
 
 // MigrationStatus reports whether one discovered migration has been applied.
+// Name is the migration file name and State is "applied" or "pending".
+// AppliedAt is nil for pending migrations.
 type MigrationStatus struct {
 	Version   int64
+	Name      string
+	State     string
 	Applied   bool
-	AppliedAt time.Time
+	AppliedAt *time.Time
 }
 
 // Status verifies that migration status can be loaded. It is retained for
@@ -169,11 +221,17 @@ func MigrationStatuses(ctx context.Context, db *sql.DB, cfg Config) ([]Migration
 
 	statuses := make([]MigrationStatus, 0, len(providerStatuses))
 	for _, status := range providerStatuses {
-		statuses = append(statuses, MigrationStatus{
-			Version:   status.Source.Version,
-			Applied:   status.State == goose.StateApplied,
-			AppliedAt: status.AppliedAt,
-		})
+		migration := MigrationStatus{
+			Version: status.Source.Version,
+			Name:    filepath.Base(status.Source.Path),
+			State:   string(status.State),
+			Applied: status.State == goose.StateApplied,
+		}
+		if migration.Applied {
+			appliedAt := status.AppliedAt
+			migration.AppliedAt = &appliedAt
+		}
+		statuses = append(statuses, migration)
 	}
 
 	return statuses, nil
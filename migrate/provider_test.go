@@ -123,10 +123,10 @@ func TestMigrationStatusesReportsAppliedAndPending(t *testing.T) {
 	if len(statuses) != 2 {
 		t.Fatalf("status count = %d, want 2", len(statuses))
 	}
-	if statuses[0].Version != 1 || !statuses[0].Applied || statuses[0].AppliedAt.IsZero() {
+	if statuses[0].Version != 1 || statuses[0].Name != "00001_init.sql" || statuses[0].State != "applied" || !statuses[0].Applied || statuses[0].AppliedAt == nil || statuses[0].AppliedAt.IsZero() {
 		t.Fatalf("first status = %#v", statuses[0])
 	}
-	if statuses[1].Version != 2 || statuses[1].Applied || !statuses[1].AppliedAt.IsZero() {
+	if statuses[1].Version != 2 || statuses[1].Name != "00002_more.sql" || statuses[1].State != "pending" || statuses[1].Applied || statuses[1].AppliedAt != nil {
 		t.Fatalf("second status = %#v", statuses[1])
 	}
 }
@@ -0,0 +1,151 @@
+package migrate
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+
+	"github.com/pressly/goose/v3"
+)
+
+// repeatableDialects lists the dialects whose DDL and upsert syntax the
+// checksum table statements use.
+var repeatableDialects = []string{"postgres", "pgx", "sqlite3"}
+
+// Repeatable applies every .sql file in cfg.RepeatableDir whose SHA-256
+// differs from the checksum recorded on its last run, in file name order, and
+// returns the names it applied. The files run through goose with versioning
+// disabled, so they need no version prefix or goose annotations and are
+// executed as written. Each file runs in its own transaction together with
+// its checksum update, so files must be safe to re-run: drop and recreate
+// views, use CREATE OR REPLACE for functions. Checksums are stored in a
+// "<Table>_repeatable" table. Run it after Up so it sees the current schema.
+// The checksum table uses PostgreSQL and SQLite upserts, so only the
+// postgres, pgx, and sqlite3 dialects are supported; any other dialect
+// returns an error before anything runs.
+//
+// Example:
+//
+//	applied, err := migrate.Repeatable(ctx, db, migrate.Config{
+//	    Dialect:       "postgres",
+//	    RepeatableDir: "migrations/repeatable",
+//	})
+func Repeatable(ctx context.Context, db *sql.DB, cfg Config) ([]string, error) {
+	if cfg.Dialect == "" {
+		return nil, fmt.Errorf("repeatable migrations failed: migration dialect is required")
+	}
+	if cfg.RepeatableDir == "" {
+		return nil, fmt.Errorf("repeatable migrations failed: repeatable directory is required")
+	}
+	if !slices.Contains(repeatableDialects, cfg.Dialect) {
+		return nil, fmt.Errorf("repeatable migrations failed: dialect %q is not supported; use postgres, pgx, or sqlite3", cfg.Dialect)
+	}
+	applied, err := applyRepeatable(ctx, db, cfg)
+	if err != nil {
+		return applied, fmt.Errorf("repeatable migrations failed: %w", err)
+	}
+	return applied, nil
+}
+
+func applyRepeatable(ctx context.Context, db *sql.DB, cfg Config) ([]string, error) {
+	fsys, err := migrationFS(Config{Dir: cfg.RepeatableDir, FS: cfg.FS})
+	if err != nil {
+		return nil, err
+	}
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", cfg.RepeatableDir, err)
+	}
+	slices.Sort(names)
+
+	table := migrationTable(cfg) + "_repeatable"
+	createTable := "CREATE TABLE IF NOT EXISTS " + table + " (name TEXT PRIMARY KEY, checksum TEXT NOT NULL, applied_at TIMESTAMP NOT NULL)"
+	selectChecksum := "SELECT checksum FROM " + table + " WHERE name = " + placeholder(cfg.Dialect, 1)
+	upsertChecksum := "INSERT INTO " + table + " (name, checksum, applied_at) VALUES (" + placeholder(cfg.Dialect, 1) + ", " + placeholder(cfg.Dialect, 2) + ", CURRENT_TIMESTAMP) " +
+		"ON CONFLICT (name) DO UPDATE SET checksum = excluded.checksum, applied_at = excluded.applied_at"
+
+	// Version 1 creates the checksum table and version i+2 runs names[i], so
+	// both happen under the lock goose takes for UseLock.
+	migrations := []*goose.Migration{goose.NewGoMigration(1, &goose.GoFunc{
+		RunTx: func(ctx context.Context, tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, createTable); err != nil {
+				return fmt.Errorf("create %s: %w", table, err)
+			}
+			return nil
+		},
+	}, nil)}
+	changed := make(map[int64]string)
+	for i, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, fmt.Errorf("read %s: %w", name, err)
+		}
+		sum := sha256.Sum256(content)
+		checksum := hex.EncodeToString(sum[:])
+		version := int64(i + 2)
+
+		migrations = append(migrations, goose.NewGoMigration(version, &goose.GoFunc{
+			RunTx: func(ctx context.Context, tx *sql.Tx) error {
+				var stored string
+				err := tx.QueryRowContext(ctx, selectChecksum, name).Scan(&stored)
+				if err != nil && !errors.Is(err, sql.ErrNoRows) {
+					return fmt.Errorf("read checksum for %s: %w", name, err)
+				}
+				if stored == checksum {
+					return nil
+				}
+				if _, err := tx.ExecContext(ctx, string(content)); err != nil {
+					return fmt.Errorf("apply %s: %w", name, err)
+				}
+				if _, err := tx.ExecContext(ctx, upsertChecksum, name, checksum); err != nil {
+					return fmt.Errorf("record checksum for %s: %w", name, err)
+				}
+				changed[version] = name
+				return nil
+			},
+		}, nil))
+	}
+
+	opts := []goose.ProviderOption{
+		goose.WithDisableVersioning(true),
+		goose.WithDisableGlobalRegistry(true),
+		goose.WithGoMigrations(migrations...),
+	}
+	lockOpt, err := lockOption(cfg, db)
+	if err != nil {
+		return nil, err
+	}
+	if lockOpt != nil {
+		opts = append(opts, lockOpt)
+	}
+	provider, err := goose.NewProvider(goose.Dialect(cfg.Dialect), db, nil, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("configure repeatable provider: %w", err)
+	}
+
+	results, err := provider.Up(ctx)
+	var partial *goose.PartialError
+	if errors.As(err, &partial) {
+		results = partial.Applied
+	}
+	var applied []string
+	for _, result := range results {
+		if name, ok := changed[result.Source.Version]; ok && result.Error == nil {
+			applied = append(applied, name)
+		}
+	}
+	return applied, err
+}
+
+// placeholder returns the n-th bind parameter for a repeatable dialect.
+func placeholder(dialect string, n int) string {
+	if dialect == "sqlite3" {
+		return "?"
+	}
+	return fmt.Sprintf("$%d", n)
+}
@@ -0,0 +1,85 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestRepeatableReappliesChangedFiles(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "repeatable")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatalf("create repeatable directory: %v", err)
+	}
+	viewPath := filepath.Join(dir, "answer_view.sql")
+	writeMigration(t, viewPath, `DROP VIEW IF EXISTS answer;
+CREATE VIEW answer AS SELECT 1 AS n;
+`)
+	writeMigration(t, filepath.Join(dir, "README.md"), "not sql")
+	db := openSQLiteForMigrationTest(t, filepath.Join(t.TempDir(), "repeatable.db"))
+	cfg := Config{Dialect: "sqlite3", RepeatableDir: dir}
+
+	assertRepeatable(t, ctx, db, cfg, "answer_view.sql")
+	assertAnswer(t, ctx, db, 1)
+	assertRepeatable(t, ctx, db, cfg)
+
+	writeMigration(t, viewPath, `DROP VIEW IF EXISTS answer;
+CREATE VIEW answer AS SELECT 2 AS n;
+`)
+	assertRepeatable(t, ctx, db, cfg, "answer_view.sql")
+	assertAnswer(t, ctx, db, 2)
+}
+
+func TestRepeatableFailureKeepsPreviousChecksum(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	writeMigration(t, filepath.Join(dir, "broken.sql"), "CREATE VIEW broken AS SELEC 1;")
+	db := openSQLiteForMigrationTest(t, filepath.Join(t.TempDir(), "broken.db"))
+	cfg := Config{Dialect: "sqlite3", RepeatableDir: dir}
+
+	if _, err := Repeatable(ctx, db, cfg); err == nil {
+		t.Fatal("broken repeatable migration unexpectedly succeeded")
+	}
+	writeMigration(t, filepath.Join(dir, "broken.sql"), "CREATE VIEW broken AS SELECT 1;")
+	assertRepeatable(t, ctx, db, cfg, "broken.sql")
+
+	if _, err := Repeatable(ctx, db, Config{Dialect: "sqlite3"}); err == nil {
+		t.Fatal("missing repeatable directory accepted")
+	}
+	if _, err := Repeatable(ctx, db, Config{Dialect: "mysql", RepeatableDir: dir}); err == nil || !strings.Contains(err.Error(), `dialect "mysql" is not supported`) {
+		t.Fatalf("mysql repeatable error = %v, want unsupported dialect", err)
+	}
+}
+
+func assertRepeatable(t *testing.T, ctx context.Context, db *sql.DB, cfg Config, want ...string) {
+	t.Helper()
+
+	applied, err := Repeatable(ctx, db, cfg)
+	if err != nil {
+		t.Fatalf("repeatable: %v", err)
+	}
+	if !slices.Equal(applied, want) {
+		t.Fatalf("applied = %v, want %v", applied, want)
+	}
+}
+
+func assertAnswer(t *testing.T, ctx context.Context, db *sql.DB, want int) {
+	t.Helper()
+
+	var n int
+	if err := db.QueryRowContext(ctx, "SELECT n FROM answer").Scan(&n); err != nil {
+		t.Fatalf("query view: %v", err)
+	}
+	if n != want {
+		t.Fatalf("answer = %d, want %d", n, want)
+	}
+}
@@ -51,6 +51,7 @@ func TestWebSourceAllowlist(t *testing.T) {
 	t.Parallel()
 	want := map[string]bool{
 		"bind.go": true, "bind_test.go": true,
+		"conditional.go": true, "conditional_test.go": true,
 		"httpserver.go": true, "httpserver_test.go": true,
 		"response.go": true,
 		"validate.go": true,
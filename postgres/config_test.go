@@ -1,9 +1,14 @@
 package postgres
 
 import (
+	"os"
+	"reflect"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -26,6 +31,69 @@ func TestConfigDSNPrecedenceAndEscaping(t *testing.T) {
 	}
 }
 
+func TestParseDSNRoundTripsDSN(t *testing.T) {
+	want := Config{
+		Host:            "db.internal",
+		Port:            6432,
+		User:            "app@tenant",
+		Password:        "p@ss?word/with#chars",
+		DBName:          "orders/eu",
+		SSLMode:         "verify-full",
+		ApplicationName: "billing worker",
+		ConnectTimeout:  5 * time.Second,
+		Params: map[string]string{
+			"sslrootcert":          "/etc/ssl/certs/db ca.pem",
+			"search_path":          "billing,public",
+			"target_session_attrs": "read-write",
+		},
+	}
+	dsn := want.DSN()
+	got, err := ParseDSN(dsn)
+	if err != nil {
+		t.Fatalf("ParseDSN(%q): %v", dsn, err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseDSN(%q) = %+v, want %+v", dsn, got, want)
+	}
+	// pgx reads sslrootcert while parsing, so check the other params alone.
+	pgxCfg := want
+	pgxCfg.Params = map[string]string{"search_path": "billing,public"}
+	connCfg, err := pgx.ParseConfig(pgxCfg.DSN())
+	if err != nil {
+		t.Fatalf("pgx.ParseConfig(%q): %v", pgxCfg.DSN(), err)
+	}
+	if got := connCfg.RuntimeParams["search_path"]; got != "billing,public" {
+		t.Fatalf("search_path = %q, want billing,public", got)
+	}
+
+	// A URL without sslmode keeps pgx's default instead of sending an
+	// empty mode.
+	got, err = ParseDSN("postgres://localhost/app?sslrootcert=%2Fca.pem")
+	if err != nil {
+		t.Fatalf("ParseDSN without sslmode: %v", err)
+	}
+	if dsn := got.DSN(); strings.Contains(dsn, "sslmode") || !strings.Contains(dsn, "sslrootcert=%2Fca.pem") {
+		t.Fatalf("DSN() = %q, want sslrootcert and no sslmode", dsn)
+	}
+
+	got, err = ParseDSN("postgresql://localhost/app")
+	if err != nil || got.Port != 5432 || got.DBName != "app" || got.User != "" {
+		t.Fatalf("ParseDSN without port = %+v, %v", got, err)
+	}
+	for _, bad := range []string{"host=localhost dbname=app", "mysql://localhost/app", "postgres://h1,h2/app", "postgres://localhost/app?connect_timeout=1.5", "postgres://localhost/app?options=a&options=b"} {
+		if _, err := ParseDSN(bad); err == nil {
+			t.Errorf("ParseDSN(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestConfigDSNRoundsConnectTimeoutUp(t *testing.T) {
+	cfg := Config{Host: "localhost", Port: 5432, DBName: "app", SSLMode: "disable", ConnectTimeout: 1500 * time.Millisecond}
+	if dsn := cfg.DSN(); !strings.Contains(dsn, "connect_timeout=2") {
+		t.Fatalf("DSN() = %q, want connect_timeout=2", dsn)
+	}
+}
+
 func TestApplyPoolConfigUsesDefaultsAndOverrides(t *testing.T) {
 	poolCfg, err := pgxpool.ParseConfig("postgres://localhost/db")
 	if err != nil {
@@ -41,3 +109,25 @@ func TestApplyPoolConfigUsesDefaultsAndOverrides(t *testing.T) {
 		t.Fatalf("custom pool config = %+v", poolCfg)
 	}
 }
+
+// TestModuleRequiresOnlyPgx keeps optional integrations such as tracing and
+// metrics out of the module every pgx user downloads. The root module is
+// required only for internal/sqltx.
+func TestModuleRequiresOnlyPgx(t *testing.T) {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var direct []string
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(strings.TrimPrefix(line, "require "))
+		if len(fields) == 2 && strings.Contains(fields[0], ".") && !strings.Contains(line, "// indirect") {
+			direct = append(direct, fields[0])
+		}
+	}
+	slices.Sort(direct)
+	want := []string{"github.com/dotcommander/gokart", "github.com/jackc/pgx/v5"}
+	if !slices.Equal(direct, want) {
+		t.Fatalf("direct requirements = %v, want %v", direct, want)
+	}
+}
@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ScanJSON scans a single JSON or JSONB column from row and unmarshals it
+// into dest. A SQL NULL leaves dest at its zero value.
+//
+// Example:
+//
+//	var prefs Preferences
+//	row := pool.QueryRow(ctx, "SELECT prefs FROM users WHERE id = $1", id)
+//	if err := postgres.ScanJSON(row, &prefs); err != nil {
+//	    return err
+//	}
+func ScanJSON[T any](row pgx.Row, dest *T) error {
+	if row == nil {
+		return fmt.Errorf("scan json: nil row")
+	}
+	if dest == nil {
+		return fmt.Errorf("scan json: nil destination")
+	}
+	var data []byte
+	if err := row.Scan(&data); err != nil {
+		return fmt.Errorf("scan json: %w", err)
+	}
+	var value T
+	if data != nil {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("unmarshal json column: %w", err)
+		}
+	}
+	*dest = value
+	return nil
+}
+
+// JSONValue marshals v for use as a JSON or JSONB query argument. pgx sends
+// the returned json.RawMessage unchanged, so the column receives exactly the
+// encoding/json representation of v.
+//
+// Example:
+//
+//	prefs, err := postgres.JSONValue(Preferences{Theme: "dark"})
+//	if err != nil {
+//	    return err
+//	}
+//	_, err = pool.Exec(ctx, "UPDATE users SET prefs = $1 WHERE id = $2", prefs, id)
+func JSONValue[T any](v T) (json.RawMessage, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal json value: %w", err)
+	}
+	return data, nil
+}
+
+// QueryJSON runs a query that returns one JSON or JSONB column and decodes
+// the first row into T. It returns pgx.ErrNoRows when the query has no rows.
+//
+// Example:
+//
+//	prefs, err := postgres.QueryJSON[Preferences](ctx, pool,
+//	    "SELECT prefs FROM users WHERE id = $1", id)
+func QueryJSON[T any](ctx context.Context, pool *pgxpool.Pool, sql string, args ...any) (T, error) {
+	var result T
+	if pool == nil {
+		return result, fmt.Errorf("query json: nil pool")
+	}
+	if err := ScanJSON(pool.QueryRow(ctx, sql, args...), &result); err != nil {
+		return result, err
+	}
+	return result, nil
+}
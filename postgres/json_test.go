@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type jsonTestAddress struct {
+	City string   `json:"city"`
+	Tags []string `json:"tags"`
+}
+
+type jsonTestDocument struct {
+	Name    string            `json:"name"`
+	Count   int               `json:"count"`
+	Active  bool              `json:"active"`
+	Address jsonTestAddress   `json:"address"`
+	Labels  map[string]string `json:"labels"`
+	Parent  *jsonTestAddress  `json:"parent"`
+}
+
+// jsonRow replays a column value the way pgx scans json/jsonb into *[]byte.
+type jsonRow struct {
+	data []byte
+	err  error
+}
+
+func (r jsonRow) Scan(dest ...any) error {
+	if r.err != nil {
+		return r.err
+	}
+	*dest[0].(*[]byte) = r.data
+	return nil
+}
+
+func TestJSONValueScanJSONRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for name, doc := range map[string]jsonTestDocument{
+		"zero": {},
+		"nested": {
+			Name:    "Ada",
+			Count:   3,
+			Active:  true,
+			Address: jsonTestAddress{City: "London", Tags: []string{"home", "work"}},
+			Labels:  map[string]string{"tier": "gold"},
+			Parent:  &jsonTestAddress{City: "Paris"},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			value, err := JSONValue(doc)
+			if err != nil {
+				t.Fatalf("JSONValue() error = %v", err)
+			}
+			var got jsonTestDocument
+			if err := ScanJSON(jsonRow{data: value}, &got); err != nil {
+				t.Fatalf("ScanJSON() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, doc) {
+				t.Fatalf("round trip = %#v, want %#v", got, doc)
+			}
+		})
+	}
+}
+
+func TestScanJSONNullResetsDestination(t *testing.T) {
+	t.Parallel()
+
+	got := jsonTestDocument{Name: "stale"}
+	if err := ScanJSON(jsonRow{}, &got); err != nil {
+		t.Fatalf("ScanJSON() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, jsonTestDocument{}) {
+		t.Fatalf("ScanJSON(NULL) = %#v, want zero value", got)
+	}
+}
+
+func TestScanJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	var doc jsonTestDocument
+	if err := ScanJSON(jsonRow{err: pgx.ErrNoRows}, &doc); !errors.Is(err, pgx.ErrNoRows) {
+		t.Fatalf("ScanJSON() error = %v, want pgx.ErrNoRows", err)
+	}
+	if err := ScanJSON(jsonRow{data: []byte("{bad")}, &doc); err == nil || !strings.Contains(err.Error(), "unmarshal") {
+		t.Fatalf("ScanJSON() error = %v, want unmarshal error", err)
+	}
+	if err := ScanJSON[jsonTestDocument](jsonRow{}, nil); err == nil {
+		t.Fatal("ScanJSON() with nil destination succeeded")
+	}
+	if err := ScanJSON(nil, &doc); err == nil {
+		t.Fatal("ScanJSON() with nil row succeeded")
+	}
+}
+
+func TestJSONValueRejectsUnsupportedTypes(t *testing.T) {
+	t.Parallel()
+
+	if _, err := JSONValue(make(chan int)); err == nil {
+		t.Fatal("JSONValue(chan) succeeded")
+	}
+}
+
+func TestQueryJSONRejectsNilPool(t *testing.T) {
+	_, err := QueryJSON[jsonTestDocument](context.Background(), nil, "SELECT '{}'::jsonb")
+	if err == nil || !strings.Contains(err.Error(), "nil pool") {
+		t.Fatalf("QueryJSON error = %v, want nil pool error", err)
+	}
+}
@@ -0,0 +1,102 @@
+// Package metrics records PostgreSQL query latency for Prometheus through a
+// pgx.QueryTracer. It is a separate module so that only applications that
+// export metrics depend on the Prometheus client.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// queryOperations bounds the operation label; other statements are recorded
+// as "OTHER".
+var queryOperations = map[string]bool{
+	"SELECT": true, "INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true,
+	"WITH": true, "BEGIN": true, "COMMIT": true, "ROLLBACK": true, "SAVEPOINT": true,
+	"RELEASE": true, "CREATE": true, "ALTER": true, "DROP": true, "TRUNCATE": true,
+	"COPY": true, "CALL": true, "SET": true, "SHOW": true, "LOCK": true,
+}
+
+// QueryTracer is a pgx.QueryTracer that observes
+// postgres_query_duration_seconds, a histogram of query latency labelled by
+// leading SQL keyword.
+type QueryTracer struct {
+	duration *prometheus.HistogramVec
+}
+
+type queryStartKey struct{}
+
+type queryStart struct {
+	operation string
+	at        time.Time
+}
+
+// NewQueryTracer registers postgres_query_duration_seconds with registry and
+// returns the tracer that records it. Registering twice in one registry
+// returns an error; wrap the registry with prometheus.WrapRegistererWith for
+// a second pool.
+//
+// Example:
+//
+//	tracer, err := metrics.NewQueryTracer(prometheus.DefaultRegisterer)
+//	cfg := postgres.DefaultConfig(url)
+//	cfg.Tracer = tracer
+//	pool, err := postgres.OpenWithConfig(ctx, cfg)
+func NewQueryTracer(registry prometheus.Registerer) (*QueryTracer, error) {
+	if registry == nil {
+		return nil, fmt.Errorf("register postgres metrics: nil registry")
+	}
+	t := &QueryTracer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "postgres_query_duration_seconds",
+			Help:    "PostgreSQL query latency in seconds, by leading SQL keyword.",
+			Buckets: prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"operation"}),
+	}
+	if err := registry.Register(t.duration); err != nil {
+		return nil, fmt.Errorf("register postgres metrics: %w", err)
+	}
+	return t, nil
+}
+
+// TraceQueryStart implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	return context.WithValue(ctx, queryStartKey{}, queryStart{operation: queryOperation(data.SQL), at: time.Now()})
+}
+
+// TraceQueryEnd implements pgx.QueryTracer.
+func (t *QueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	if start, ok := ctx.Value(queryStartKey{}).(queryStart); ok {
+		t.duration.WithLabelValues(start.operation).Observe(time.Since(start.at).Seconds())
+	}
+}
+
+// queryOperation returns the upper-cased leading keyword of sql, skipping
+// whitespace, comments, and opening parentheses.
+func queryOperation(sql string) string {
+	for {
+		sql = strings.TrimLeft(sql, " \t\r\n(")
+		switch {
+		case strings.HasPrefix(sql, "--"):
+			_, sql, _ = strings.Cut(sql, "\n")
+		case strings.HasPrefix(sql, "/*"):
+			_, sql, _ = strings.Cut(sql, "*/")
+		default:
+			end := strings.IndexFunc(sql, func(r rune) bool {
+				return !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z')
+			})
+			if end >= 0 {
+				sql = sql[:end]
+			}
+			if operation := strings.ToUpper(sql); queryOperations[operation] {
+				return operation
+			}
+			return "OTHER"
+		}
+	}
+}
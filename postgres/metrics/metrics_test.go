@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestQueryOperation(t *testing.T) {
+	for sql, want := range map[string]string{
+		"SELECT 1":                    "SELECT",
+		"  insert into t values ($1)": "INSERT",
+		"-- load user\nupdate users set name = $1":   "UPDATE",
+		"/* report */ WITH x AS (SELECT 1) SELECT *": "WITH",
+		"(select 1) union (select 2)":                "SELECT",
+		"VACUUM ANALYZE users":                       "OTHER",
+		"":                                           "OTHER",
+		"delete\tfrom t":                             "DELETE",
+	} {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
+
+func TestNewQueryTracerRegistersOnce(t *testing.T) {
+	if _, err := NewQueryTracer(nil); err == nil {
+		t.Fatal("nil registry accepted")
+	}
+	registry := prometheus.NewPedanticRegistry()
+	tracer, err := NewQueryTracer(registry)
+	if err != nil {
+		t.Fatalf("NewQueryTracer: %v", err)
+	}
+	var _ pgx.QueryTracer = tracer
+	if _, err := NewQueryTracer(registry); err == nil {
+		t.Fatal("registering twice in one registry succeeded")
+	}
+}
+
+func TestQueryTracerObservesByOperation(t *testing.T) {
+	registry := prometheus.NewPedanticRegistry()
+	tracer, err := NewQueryTracer(registry)
+	if err != nil {
+		t.Fatalf("NewQueryTracer: %v", err)
+	}
+	for _, sql := range []string{"INSERT INTO t VALUES (1)", "INSERT INTO t VALUES (2)", "SELECT 1"} {
+		ctx := tracer.TraceQueryStart(t.Context(), nil, pgx.TraceQueryStartData{SQL: sql})
+		tracer.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{})
+	}
+	tracer.TraceQueryEnd(t.Context(), nil, pgx.TraceQueryEndData{})
+
+	counts := gatherCounts(t, registry)
+	if len(counts) != 2 || counts["INSERT"] != 2 || counts["SELECT"] != 1 {
+		t.Fatalf("observations by operation = %v", counts)
+	}
+}
+
+func TestPoolRecordsQueryDurations(t *testing.T) {
+	url := os.Getenv("GOKART_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("set GOKART_TEST_POSTGRES_URL to run PostgreSQL integration tests")
+	}
+	registry := prometheus.NewPedanticRegistry()
+	tracer, err := NewQueryTracer(registry)
+	if err != nil {
+		t.Fatalf("NewQueryTracer: %v", err)
+	}
+	poolCfg, err := pgxpool.ParseConfig(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	poolCfg.ConnConfig.Tracer = tracer
+	pool, err := pgxpool.NewWithConfig(t.Context(), poolCfg)
+	if err != nil {
+		t.Fatalf("NewWithConfig: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := t.Context()
+	for _, sql := range []string{
+		"CREATE TEMP TABLE measured (id int)",
+		"INSERT INTO measured (id) VALUES (1)",
+		"INSERT INTO measured (id) VALUES (2)",
+		"SELECT count(*) FROM measured",
+	} {
+		if _, err := pool.Exec(ctx, sql); err != nil {
+			t.Fatalf("%s: %v", sql, err)
+		}
+	}
+
+	counts := gatherCounts(t, registry)
+	if counts["INSERT"] != 2 || counts["CREATE"] != 1 || counts["SELECT"] < 1 {
+		t.Fatalf("observations by operation = %v", counts)
+	}
+}
+
+func gatherCounts(t *testing.T, registry *prometheus.Registry) map[string]uint64 {
+	t.Helper()
+	families, err := registry.Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+	counts := map[string]uint64{}
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			counts[metric.GetLabel()[0].GetValue()] = metric.GetHistogram().GetSampleCount()
+		}
+	}
+	return counts
+}
@@ -7,6 +7,7 @@ import (
 	"net"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/dotcommander/gokart/internal/sqltx"
@@ -27,6 +28,19 @@ type Config struct {
 	DBName   string `config:"dbname" default:"postgres"`
 	SSLMode  string `config:"sslmode" default:"disable"`
 
+	// ApplicationName is reported in pg_stat_activity.
+	ApplicationName string `config:"application_name"`
+
+	// ConnectTimeout bounds each connection attempt; DSN rounds it up to
+	// whole seconds.
+	// Default: no timeout
+	ConnectTimeout time.Duration `config:"connect_timeout"`
+
+	// Params holds other connection parameters, such as sslrootcert,
+	// search_path, or target_session_attrs, that DSN adds to the assembled
+	// URL. The fields above take precedence over the same keys here.
+	Params map[string]string `config:"params"`
+
 	// ConnectionString is retained for config-map compatibility.
 	// Deprecated: use URL.
 	ConnectionString string `config:"connection_string"`
@@ -50,6 +64,18 @@ type Config struct {
 	// HealthCheckPeriod is how often to check connection health.
 	// Default: 1 minute
 	HealthCheckPeriod time.Duration `config:"health_check_period"`
+
+	// Tracer, when set, is installed as the pool's pgx.QueryTracer. For
+	// OpenTelemetry spans pass otelpgx.NewTracer() from
+	// github.com/exaring/otelpgx, and for query metrics the tracer from the
+	// gokart/postgres/metrics module; combine several with pgx's multitracer.
+	// Default: no tracing
+	Tracer pgx.QueryTracer `config:"-"`
+
+	// WarmupOnOpen makes OpenWithConfig call Warmup, so MinConns connections
+	// are established before the first request instead of lazily.
+	// Default: false
+	WarmupOnOpen bool `config:"warmup_on_open"`
 }
 
 // PostgresConfig is retained for compatibility with applications that used
@@ -72,12 +98,84 @@ func (c Config) DSN() string {
 		Path:    "/" + c.DBName,
 		RawPath: "/" + url.PathEscape(c.DBName),
 	}
-	query := dsn.Query()
-	query.Set("sslmode", c.SSLMode)
+	query := url.Values{}
+	for key, value := range c.Params {
+		query.Set(key, value)
+	}
+	if c.SSLMode != "" {
+		query.Set("sslmode", c.SSLMode)
+	}
+	if c.ApplicationName != "" {
+		query.Set("application_name", c.ApplicationName)
+	}
+	if c.ConnectTimeout > 0 {
+		seconds := (c.ConnectTimeout + time.Second - 1) / time.Second
+		query.Set("connect_timeout", strconv.FormatInt(int64(seconds), 10))
+	}
 	dsn.RawQuery = query.Encode()
 	return dsn.String()
 }
 
+// ParseDSN splits a postgres:// or postgresql:// URL into the discrete
+// connection fields DSN assembles, decoding percent-escaped credentials and
+// database names. Port defaults to 5432, and SSLMode stays empty when the
+// URL has no sslmode, so pgx keeps its default. Query parameters without a
+// field, such as sslrootcert or search_path, go to Params. Keyword/value
+// connection strings, multi-host URLs, and repeated parameters are
+// rejected; pass those to Open unchanged.
+//
+// Example:
+//
+//	cfg, err := postgres.ParseDSN(os.Getenv("DATABASE_URL"))
+//	cfg.ApplicationName = "worker"
+//	pool, err := postgres.OpenWithConfig(ctx, cfg)
+func ParseDSN(dsn string) (Config, error) {
+	u, err := url.Parse(dsn)
+	if err != nil {
+		return Config{}, fmt.Errorf("parse postgres dsn: %w", err)
+	}
+	if u.Scheme != "postgres" && u.Scheme != "postgresql" {
+		return Config{}, fmt.Errorf("parse postgres dsn: scheme %q is not postgres or postgresql", u.Scheme)
+	}
+	cfg := Config{Host: u.Hostname(), Port: 5432, DBName: strings.TrimPrefix(u.Path, "/")}
+	if strings.Contains(u.Host, ",") {
+		return Config{}, fmt.Errorf("parse postgres dsn: multiple hosts are not supported")
+	}
+	if port := u.Port(); port != "" {
+		if cfg.Port, err = strconv.Atoi(port); err != nil {
+			return Config{}, fmt.Errorf("parse postgres dsn: port %q: %w", port, err)
+		}
+	}
+	if u.User != nil {
+		cfg.User = u.User.Username()
+		cfg.Password, _ = u.User.Password()
+	}
+	query := u.Query()
+	for key, values := range query {
+		if len(values) > 1 {
+			return Config{}, fmt.Errorf("parse postgres dsn: parameter %q is repeated", key)
+		}
+		switch key {
+		case "sslmode", "application_name", "connect_timeout":
+		default:
+			if cfg.Params == nil {
+				cfg.Params = map[string]string{}
+			}
+			cfg.Params[key] = values[0]
+		}
+	}
+	cfg.SSLMode = query.Get("sslmode")
+	cfg.ApplicationName = query.Get("application_name")
+	if timeout := query.Get("connect_timeout"); timeout != "" {
+		seconds, err := strconv.Atoi(timeout)
+		if err != nil || seconds < 0 {
+			return Config{}, fmt.Errorf("parse postgres dsn: connect_timeout %q is not a whole number of seconds", timeout)
+		}
+		cfg.ConnectTimeout = time.Duration(seconds) * time.Second
+	}
+	return cfg, nil
+}
+
 // BuildConnectionString is a compatibility alias for DSN.
 func (c Config) BuildConnectionString() string { return c.DSN() }
 
@@ -136,6 +234,13 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
 		return nil, fmt.Errorf("ping postgres: %w", err)
 	}
 
+	if cfg.WarmupOnOpen {
+		if err := Warmup(ctx, pool); err != nil {
+			pool.Close()
+			return nil, err
+		}
+	}
+
 	return pool, nil
 }
 
@@ -176,6 +281,9 @@ func applyPoolConfig(poolCfg *pgxpool.Config, cfg Config) {
 	poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
 	poolCfg.MaxConnIdleTime = cfg.MaxConnIdleTime
 	poolCfg.HealthCheckPeriod = cfg.HealthCheckPeriod
+	if cfg.Tracer != nil {
+		poolCfg.ConnConfig.Tracer = cfg.Tracer
+	}
 }
 
 // FromEnv opens a PostgreSQL pool using DATABASE_URL environment variable.
@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolStats is a JSON-encodable snapshot of pgxpool.Stat.
+type PoolStats struct {
+	AcquiredConns         int32         `json:"acquired_conns"`
+	IdleConns             int32         `json:"idle_conns"`
+	TotalConns            int32         `json:"total_conns"`
+	MaxConns              int32         `json:"max_conns"`
+	ConstructingConns     int32         `json:"constructing_conns"`
+	AcquireCount          int64         `json:"acquire_count"`
+	AcquireDuration       time.Duration `json:"acquire_duration_ns"`
+	CancelledAcquireCount int64         `json:"cancelled_acquire_count"`
+	EmptyAcquireCount     int64         `json:"empty_acquire_count"`
+}
+
+// ReadPoolStats returns a snapshot of pool's connection counters. A nil pool
+// reports zero values.
+func ReadPoolStats(pool *pgxpool.Pool) PoolStats {
+	if pool == nil {
+		return PoolStats{}
+	}
+	stat := pool.Stat()
+	return PoolStats{
+		AcquiredConns:         stat.AcquiredConns(),
+		IdleConns:             stat.IdleConns(),
+		TotalConns:            stat.TotalConns(),
+		MaxConns:              stat.MaxConns(),
+		ConstructingConns:     stat.ConstructingConns(),
+		AcquireCount:          stat.AcquireCount(),
+		AcquireDuration:       stat.AcquireDuration(),
+		CancelledAcquireCount: stat.CanceledAcquireCount(),
+		EmptyAcquireCount:     stat.EmptyAcquireCount(),
+	}
+}
+
+// StatsHandler serves ReadPoolStats(pool) as JSON. Mount it on an internal route;
+// the counters reveal load but no connection details.
+//
+// Example:
+//
+//	router.Handle("/debug/postgres", postgres.StatsHandler(pool))
+func StatsHandler(pool *pgxpool.Pool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(ReadPoolStats(pool))
+	})
+}
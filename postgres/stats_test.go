@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func TestStatsHandlerServesJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+	StatsHandler(nil).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/postgres", nil))
+
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", got)
+	}
+	var stats map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+	}
+	if stats["acquired_conns"] != float64(0) || stats["max_conns"] != float64(0) {
+		t.Fatalf("nil pool stats = %v, want zero values", stats)
+	}
+}
+
+func TestStatsReportsAcquiredConns(t *testing.T) {
+	cfg := DefaultConfig(testPostgresURL(t))
+	cfg.MaxConns = 4
+	cfg.MinConns = 1
+	pool, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig: %v", err)
+	}
+	defer pool.Close()
+
+	var conns []*pgxpool.Conn
+	for range 2 {
+		conn, err := pool.Acquire(t.Context())
+		if err != nil {
+			t.Fatalf("Acquire: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	stats := ReadPoolStats(pool)
+	if stats.AcquiredConns != 2 || stats.MaxConns != 4 || stats.AcquireCount < 2 {
+		t.Fatalf("stats with two acquired = %+v", stats)
+	}
+
+	for _, conn := range conns {
+		conn.Release()
+	}
+	rec := httptest.NewRecorder()
+	StatsHandler(pool).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/postgres", nil))
+	var served PoolStats
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if served.AcquiredConns != 0 || served.IdleConns < 2 {
+		t.Fatalf("served stats after release = %+v", served)
+	}
+}
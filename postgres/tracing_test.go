@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// recordingTracer is a pgx.QueryTracer that records the SQL of each query.
+type recordingTracer struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (r *recordingTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.queries = append(r.queries, data.SQL)
+	return ctx
+}
+
+func (r *recordingTracer) TraceQueryEnd(context.Context, *pgx.Conn, pgx.TraceQueryEndData) {}
+
+func TestApplyPoolConfigInstallsTracerOnlyWhenSet(t *testing.T) {
+	poolCfg, err := pgxpool.ParseConfig("postgres://localhost/db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	applyPoolConfig(poolCfg, Config{})
+	if poolCfg.ConnConfig.Tracer != nil {
+		t.Fatalf("tracer installed without one configured: %T", poolCfg.ConnConfig.Tracer)
+	}
+	tracer := &recordingTracer{}
+	applyPoolConfig(poolCfg, Config{Tracer: tracer})
+	if poolCfg.ConnConfig.Tracer != tracer {
+		t.Fatalf("tracer = %T, want the configured tracer", poolCfg.ConnConfig.Tracer)
+	}
+}
+
+// testPostgresURL returns GOKART_TEST_POSTGRES_URL, skipping the test when no
+// server is configured.
+func testPostgresURL(t *testing.T) string {
+	t.Helper()
+	url := os.Getenv("GOKART_TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("set GOKART_TEST_POSTGRES_URL to run PostgreSQL integration tests")
+	}
+	return url
+}
+
+func TestOpenWithConfigTracesQueries(t *testing.T) {
+	tracer := &recordingTracer{}
+	cfg := DefaultConfig(testPostgresURL(t))
+	cfg.Tracer = tracer
+	pool, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig: %v", err)
+	}
+	defer pool.Close()
+
+	ctx := t.Context()
+	if _, err := pool.Exec(ctx, "CREATE TEMP TABLE traced (id int)"); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := pool.Exec(ctx, "INSERT INTO traced (id) VALUES (1)"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	var id int
+	if err := pool.QueryRow(ctx, "SELECT id FROM traced").Scan(&id); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+
+	tracer.mu.Lock()
+	defer tracer.mu.Unlock()
+	found := map[string]bool{}
+	for _, sql := range tracer.queries {
+		for _, verb := range []string{"SELECT", "INSERT"} {
+			if strings.HasPrefix(sql, verb) {
+				found[verb] = true
+			}
+		}
+	}
+	if !found["SELECT"] || !found["INSERT"] {
+		t.Fatalf("traced queries %q, want SELECT and INSERT", tracer.queries)
+	}
+}
@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Warmup establishes the pool's MinConns connections by acquiring and
+// pinging them concurrently, then returns them to the pool as idle
+// connections. pgxpool otherwise fills MinConns in the background, so the
+// first requests after startup can pay for connection setup.
+//
+// Example:
+//
+//	pool, err := postgres.Open(ctx, url)
+//	if err != nil {
+//	    return err
+//	}
+//	if err := postgres.Warmup(ctx, pool); err != nil {
+//	    return err
+//	}
+func Warmup(ctx context.Context, pool *pgxpool.Pool) error {
+	if pool == nil {
+		return fmt.Errorf("warmup: nil pool")
+	}
+	n := int(pool.Config().MinConns)
+	conns := make([]*pgxpool.Conn, n)
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Go(func() {
+			conn, err := pool.Acquire(ctx)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			conns[i] = conn
+			errs[i] = conn.Ping(ctx)
+		})
+	}
+	wg.Wait()
+	// Release only after every acquire finished so each goroutine had to
+	// open a distinct connection.
+	for _, conn := range conns {
+		if conn != nil {
+			conn.Release()
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("warmup postgres pool: %w", err)
+	}
+	return nil
+}
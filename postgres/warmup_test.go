@@ -0,0 +1,49 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWarmupRejectsNilPool(t *testing.T) {
+	err := Warmup(context.Background(), nil)
+	if err == nil || !strings.Contains(err.Error(), "nil pool") {
+		t.Fatalf("Warmup error = %v, want nil pool error", err)
+	}
+}
+
+func TestWarmupEstablishesMinConns(t *testing.T) {
+	cfg := DefaultConfig(testPostgresURL(t))
+	cfg.MinConns = 3
+	cfg.MaxConns = 5
+	pool, err := cfg.NewPool(t.Context())
+	if err != nil {
+		t.Fatalf("NewPool: %v", err)
+	}
+	defer pool.Close()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 5*time.Second)
+	defer cancel()
+	if err := Warmup(ctx, pool); err != nil {
+		t.Fatalf("Warmup: %v", err)
+	}
+	if idle := pool.Stat().IdleConns(); idle < cfg.MinConns {
+		t.Fatalf("IdleConns = %d, want at least %d", idle, cfg.MinConns)
+	}
+}
+
+func TestOpenWithConfigWarmupOnOpen(t *testing.T) {
+	cfg := DefaultConfig(testPostgresURL(t))
+	cfg.MinConns = 2
+	cfg.WarmupOnOpen = true
+	pool, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig: %v", err)
+	}
+	defer pool.Close()
+	if idle := pool.Stat().IdleConns(); idle < cfg.MinConns {
+		t.Fatalf("IdleConns = %d, want at least %d", idle, cfg.MinConns)
+	}
+}
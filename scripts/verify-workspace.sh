@@ -45,6 +45,23 @@ done
 export GOWORK="$verify_workspace"
 export GOMODCACHE="$verify_gomodcache"
 
+# testutil requires its sibling modules at the pending release version, so it
+# stays out of go.work, where those requirements would have to resolve, and is
+# checked in a copy that replaces them with this checkout.
+standalone_testutil="$verify_home/standalone-testutil"
+
+prepare_standalone_testutil() {
+    local local_module module_path
+
+    [[ -d "$standalone_testutil" ]] && return
+    cp -R testutil "$standalone_testutil"
+    for local_module in "${workspace_modules[@]}"; do
+        [[ "$local_module" == "." ]] && continue
+        module_path="$(awk '$1 == "module" { print $2; exit }' "$local_module/go.mod")"
+        (cd "$standalone_testutil" && GOWORK=off go mod edit -replace="$module_path=$repo_root/$local_module")
+    done
+}
+
 run_workspace() {
     local action="$1"
 
@@ -56,6 +73,14 @@ run_workspace() {
             vet) (cd "$module" && GOCACHE="$verify_gocache" go vet ./...) ;;
         esac
     done
+
+    prepare_standalone_testutil
+    echo "== $action testutil =="
+    case "$action" in
+        build) (cd "$standalone_testutil" && GOCACHE="$verify_gocache" GOWORK=off go build ./...) ;;
+        test) (cd "$standalone_testutil" && HOME="$verify_home" GOCACHE="$verify_gocache" GOWORK=off go test ./...) ;;
+        vet) (cd "$standalone_testutil" && GOCACHE="$verify_gocache" GOWORK=off go vet ./...) ;;
+    esac
 }
 
 compile_ignored_examples() {
@@ -84,6 +109,19 @@ verify_standalone_modules() {
         echo "== standalone test $module =="
         (cd "$module" && HOME="$verify_home" GOCACHE="$verify_gocache" GOWORK=off go test -mod=readonly ./...)
     done
+    verify_standalone_testutil
+}
+
+verify_standalone_testutil() {
+    local target="$standalone_testutil"
+
+    prepare_standalone_testutil
+    echo "== standalone tidy testutil =="
+    (cd "$target" && HOME="$verify_home" GOCACHE="$verify_gocache" GOWORK=off go mod tidy -diff)
+    echo "== standalone verify testutil =="
+    (cd "$target" && HOME="$verify_home" GOCACHE="$verify_gocache" GOWORK=off go mod verify)
+    echo "== standalone test testutil =="
+    (cd "$target" && HOME="$verify_home" GOCACHE="$verify_gocache" GOWORK=off go test -mod=readonly ./...)
 }
 
 verify_standalone_examples() {
@@ -0,0 +1,139 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// FTS5Config describes an FTS5 virtual table. ContentTable makes it an
+// external-content index over an existing table whose integer key is
+// ContentRowID (default "rowid"); Triggers keeps that index in sync.
+type FTS5Config struct {
+	TableName    string
+	ContentTable string
+	ContentRowID string
+	Columns      []string
+	Tokenizer    string
+	Triggers     bool
+}
+
+// CreateFTS5Table creates the FTS5 table described by cfg, plus insert,
+// update, and delete triggers when cfg.Triggers is set, in one transaction.
+// External-content indexes are rebuilt so existing rows become searchable.
+func CreateFTS5Table(ctx context.Context, db *sql.DB, cfg FTS5Config) error {
+	if db == nil {
+		return fmt.Errorf("create fts5 table: nil db")
+	}
+	statements, err := fts5Statements(cfg)
+	if err != nil {
+		return fmt.Errorf("create fts5 table: %w", err)
+	}
+	return Transaction(ctx, db, func(tx *sql.Tx) error {
+		for _, statement := range statements {
+			if _, err := tx.ExecContext(ctx, statement); err != nil {
+				return fmt.Errorf("create fts5 table %s: %w", cfg.TableName, err)
+			}
+		}
+		return nil
+	})
+}
+
+// FTS5Search returns the rowids matching an FTS5 query, best match first.
+// A non-positive limit returns every match.
+func FTS5Search(ctx context.Context, db *sql.DB, table, query string, limit int) ([]int64, error) {
+	if db == nil {
+		return nil, fmt.Errorf("fts5 search: nil db")
+	}
+	if !validIdentifier(table) {
+		return nil, fmt.Errorf("fts5 search: invalid table name %q", table)
+	}
+	if limit <= 0 {
+		limit = -1
+	}
+	rows, err := db.QueryContext(ctx, "SELECT rowid FROM "+table+" WHERE "+table+" MATCH ? ORDER BY rank LIMIT ?", query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("fts5 search %s: %w", table, err)
+	}
+	defer rows.Close()
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("fts5 search result: %w", err)
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("fts5 search rows: %w", err)
+	}
+	return ids, nil
+}
+
+func fts5Statements(cfg FTS5Config) ([]string, error) {
+	if !validIdentifier(cfg.TableName) {
+		return nil, fmt.Errorf("invalid table name %q", cfg.TableName)
+	}
+	if len(cfg.Columns) == 0 {
+		return nil, fmt.Errorf("at least one column is required")
+	}
+	for _, column := range cfg.Columns {
+		if !validIdentifier(column) {
+			return nil, fmt.Errorf("invalid column name %q", column)
+		}
+	}
+	if cfg.ContentTable != "" && !validIdentifier(cfg.ContentTable) {
+		return nil, fmt.Errorf("invalid content table name %q", cfg.ContentTable)
+	}
+	rowID := cfg.ContentRowID
+	if rowID == "" {
+		rowID = "rowid"
+	}
+	if !validIdentifier(rowID) {
+		return nil, fmt.Errorf("invalid content rowid %q", rowID)
+	}
+	if cfg.Triggers && cfg.ContentTable == "" {
+		return nil, fmt.Errorf("triggers require a content table")
+	}
+	if strings.Trim(cfg.Tokenizer, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_ ") != "" {
+		return nil, fmt.Errorf("invalid tokenizer %q", cfg.Tokenizer)
+	}
+
+	args := append([]string(nil), cfg.Columns...)
+	if cfg.ContentTable != "" {
+		args = append(args, "content='"+cfg.ContentTable+"'", "content_rowid='"+rowID+"'")
+	}
+	if cfg.Tokenizer != "" {
+		args = append(args, "tokenize='"+cfg.Tokenizer+"'")
+	}
+	statements := []string{fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(%s)", cfg.TableName, strings.Join(args, ", "))}
+	if cfg.ContentTable == "" {
+		return statements, nil
+	}
+
+	table, columns := cfg.TableName, strings.Join(cfg.Columns, ", ")
+	values := func(alias string) string {
+		refs := make([]string, 0, len(cfg.Columns)+1)
+		refs = append(refs, alias+"."+rowID)
+		for _, column := range cfg.Columns {
+			refs = append(refs, alias+"."+column)
+		}
+		return strings.Join(refs, ", ")
+	}
+	insert := fmt.Sprintf("INSERT INTO %s(rowid, %s) VALUES (%s);", table, columns, values("new"))
+	remove := fmt.Sprintf("INSERT INTO %s(%s, rowid, %s) VALUES ('delete', %s);", table, table, columns, values("old"))
+	if cfg.Triggers {
+		statements = append(statements,
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s_ai AFTER INSERT ON %s BEGIN %s END", table, cfg.ContentTable, insert),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s_ad AFTER DELETE ON %s BEGIN %s END", table, cfg.ContentTable, remove),
+			fmt.Sprintf("CREATE TRIGGER IF NOT EXISTS %s_au AFTER UPDATE ON %s BEGIN %s %s END", table, cfg.ContentTable, remove, insert),
+		)
+	}
+	return append(statements, fmt.Sprintf("INSERT INTO %s(%s) VALUES ('rebuild')", table, table)), nil
+}
+
+// validIdentifier accepts unquoted ASCII SQL identifiers.
+func validIdentifier(name string) bool {
+	return validSavepointName(name)
+}
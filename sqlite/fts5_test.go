@@ -0,0 +1,93 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"testing"
+)
+
+func TestCreateFTS5TableWithTriggers(t *testing.T) {
+	ctx := context.Background()
+	db, err := InMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE note (id INTEGER PRIMARY KEY, title TEXT, body TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec("INSERT INTO note (id, title, body) VALUES (1, 'existing', 'indexed by rebuild')"); err != nil {
+		t.Fatal(err)
+	}
+	cfg := FTS5Config{TableName: "note_fts", ContentTable: "note", ContentRowID: "id", Columns: []string{"title", "body"}, Tokenizer: "porter unicode61", Triggers: true}
+	if err := CreateFTS5Table(ctx, db, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Exec(`INSERT INTO note (id, title, body) VALUES
+		(2, 'gardening', 'tomatoes need sun'),
+		(3, 'cooking', 'tomato soup recipe'),
+		(4, 'travel', 'trains across europe')`); err != nil {
+		t.Fatal(err)
+	}
+
+	assertFTS5Search(t, db, "tomato", 2, 3)
+	assertFTS5Search(t, db, "rebuild", 1)
+	if limited, err := FTS5Search(ctx, db, "note_fts", "tomato", 1); err != nil || len(limited) != 1 {
+		t.Fatalf("limited=%v err=%v", limited, err)
+	}
+
+	if _, err := db.Exec("UPDATE note SET body = 'sunny beaches' WHERE id = 2"); err != nil {
+		t.Fatal(err)
+	}
+	assertFTS5Search(t, db, "tomato", 3)
+	if _, err := db.Exec("DELETE FROM note WHERE id = 3"); err != nil {
+		t.Fatal(err)
+	}
+	assertFTS5Search(t, db, "tomato")
+	assertFTS5Search(t, db, "beach", 2)
+
+	if err := CreateFTS5Table(ctx, db, cfg); err != nil {
+		t.Fatalf("repeated create: %v", err)
+	}
+}
+
+func assertFTS5Search(t *testing.T, db *sql.DB, query string, want ...int64) {
+	t.Helper()
+	got, err := FTS5Search(context.Background(), db, "note_fts", query, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(got)
+	if !slices.Equal(got, want) {
+		t.Fatalf("FTS5Search(%q) = %v, want %v", query, got, want)
+	}
+}
+
+func TestFTS5ConfigValidation(t *testing.T) {
+	ctx := context.Background()
+	db, err := InMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	for name, cfg := range map[string]FTS5Config{
+		"missing table":     {Columns: []string{"body"}},
+		"missing columns":   {TableName: "docs"},
+		"injected column":   {TableName: "docs", Columns: []string{"body); DROP TABLE x; --"}},
+		"injected tokenize": {TableName: "docs", Columns: []string{"body"}, Tokenizer: "porter'"},
+		"triggers no table": {TableName: "docs", Columns: []string{"body"}, Triggers: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := CreateFTS5Table(ctx, db, cfg); err == nil {
+				t.Fatal("expected validation error")
+			}
+		})
+	}
+	if _, err := FTS5Search(ctx, db, "bad name", "x", 0); err == nil {
+		t.Fatal("expected invalid table error")
+	}
+	if err := CreateFTS5Table(ctx, nil, FTS5Config{}); err == nil {
+		t.Fatal("expected nil db error")
+	}
+}
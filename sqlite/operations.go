@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"time"
@@ -284,9 +285,226 @@ func VacuumInto(ctx context.Context, db *sql.DB, destination string) error {
 	return nil
 }
 
+// VacuumResult reports the database size in pages around a vacuum.
+type VacuumResult struct{ PagesBefore, PagesAfter int64 }
+
+// VacuumWithResult runs VACUUM and reports the page count before and after.
+func VacuumWithResult(ctx context.Context, db *sql.DB) (VacuumResult, error) {
+	before, err := pageCount(ctx, db, "vacuum")
+	if err != nil {
+		return VacuumResult{}, err
+	}
+	if err := Vacuum(ctx, db); err != nil {
+		return VacuumResult{}, err
+	}
+	after, err := pageCount(ctx, db, "vacuum")
+	if err != nil {
+		return VacuumResult{}, err
+	}
+	return VacuumResult{PagesBefore: before, PagesAfter: after}, nil
+}
+
+// VacuumIntoWithResult compacts db into destination and reports the source
+// page count and the page count of the written copy.
+func VacuumIntoWithResult(ctx context.Context, db *sql.DB, destination string) (VacuumResult, error) {
+	before, err := pageCount(ctx, db, "vacuum into")
+	if err != nil {
+		return VacuumResult{}, err
+	}
+	if err := VacuumInto(ctx, db, destination); err != nil {
+		return VacuumResult{}, err
+	}
+	copyDB, err := OpenImmutable(ctx, destination)
+	if err != nil {
+		return VacuumResult{}, fmt.Errorf("vacuum into: open copy: %w", err)
+	}
+	after, countErr := pageCount(ctx, copyDB, "vacuum into")
+	if err := errors.Join(countErr, copyDB.Close()); err != nil {
+		return VacuumResult{}, err
+	}
+	return VacuumResult{PagesBefore: before, PagesAfter: after}, nil
+}
+
+func pageCount(ctx context.Context, db *sql.DB, name string) (int64, error) {
+	if db == nil {
+		return 0, fmt.Errorf("%s: nil db", name)
+	}
+	var pages int64
+	if err := db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return 0, fmt.Errorf("%s page count: %w", name, err)
+	}
+	return pages, nil
+}
+
 type BackupOptions struct{ Overwrite bool }
 
+const (
+	// backupPagesPerStep bounds how long each online backup step holds the
+	// source read lock, and backupStepPause lets writers run between steps.
+	backupPagesPerStep = 128
+	backupStepPause    = 5 * time.Millisecond
+	// backupMaxPasses bounds the copy when writes from other connections
+	// keep restarting it; OnlineBackup then falls back to VACUUM INTO.
+	backupMaxPasses = 4
+)
+
 func Backup(ctx context.Context, db *sql.DB, destination string, opts BackupOptions) error {
+	return publishBackup(ctx, db, destination, opts, func(path string) error {
+		return VacuumInto(ctx, db, path)
+	})
+}
+
+// OnlineBackup copies a live database to destination with SQLite's online
+// backup API, page by page, without blocking writers for the whole copy.
+// The copy is verified and published like Backup.
+func OnlineBackup(ctx context.Context, db *sql.DB, destination string, opts BackupOptions) error {
+	return publishBackup(ctx, db, destination, opts, func(path string) error {
+		return onlineBackup(ctx, db, path)
+	})
+}
+
+// BackupToWriter streams an online backup of db to w, for example to upload
+// a snapshot or pipe it to stdout.
+func BackupToWriter(ctx context.Context, db *sql.DB, w io.Writer) error {
+	if db == nil {
+		return fmt.Errorf("backup to writer: nil db")
+	}
+	if w == nil {
+		return fmt.Errorf("backup to writer: nil writer")
+	}
+	dir, err := os.MkdirTemp("", "gokart-backup-*")
+	if err != nil {
+		return fmt.Errorf("backup to writer: create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "backup.db")
+	if err := onlineBackup(ctx, db, path); err != nil {
+		return fmt.Errorf("backup to writer: %w", err)
+	}
+	snapshot, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("backup to writer: open snapshot: %w", err)
+	}
+	defer snapshot.Close()
+	if _, err := io.Copy(w, snapshot); err != nil {
+		return fmt.Errorf("backup to writer: copy snapshot: %w", err)
+	}
+	return nil
+}
+
+// ScheduleBackups writes an online backup to cfg.BackupPath every
+// cfg.BackupInterval, replacing the previous snapshot. It blocks until ctx
+// is cancelled or a backup fails.
+func ScheduleBackups(ctx context.Context, db *sql.DB, cfg Config) error {
+	if db == nil {
+		return fmt.Errorf("schedule backups: nil db")
+	}
+	if cfg.BackupPath == "" || cfg.BackupInterval <= 0 {
+		return fmt.Errorf("schedule backups: BackupPath and a positive BackupInterval are required")
+	}
+	ticker := time.NewTicker(cfg.BackupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := OnlineBackup(ctx, db, cfg.BackupPath, BackupOptions{Overwrite: true}); err != nil {
+				if ctx.Err() != nil {
+					return nil
+				}
+				return fmt.Errorf("scheduled %w", err)
+			}
+		}
+	}
+}
+
+// backupConn is implemented by modernc.org/sqlite driver connections.
+type backupConn interface {
+	NewBackup(dstURI string) (*moderncsqlite.Backup, error)
+}
+
+// backupStepper is the part of *moderncsqlite.Backup that copyPages drives.
+type backupStepper interface {
+	Step(npage int32) (bool, error)
+	Finish() error
+}
+
+func onlineBackup(ctx context.Context, db *sql.DB, destination string) error {
+	complete, err := stepBackup(ctx, db, destination)
+	if err != nil || complete {
+		return err
+	}
+	// Writers kept restarting the copy. VACUUM INTO reads one consistent
+	// snapshot instead, and runs after the backup connection is returned so
+	// a single-connection pool can serve it.
+	if err := os.Remove(destination); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("online backup: remove partial copy: %w", err)
+	}
+	if err := VacuumInto(ctx, db, destination); err != nil {
+		return fmt.Errorf("online backup: %w", err)
+	}
+	return nil
+}
+
+// stepBackup runs the online backup API, reporting false when the copy did
+// not finish within backupMaxPasses passes over the source.
+func stepBackup(ctx context.Context, db *sql.DB, destination string) (bool, error) {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("online backup: acquire connection: %w", err)
+	}
+	defer conn.Close()
+	var pages int
+	if err := conn.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return false, fmt.Errorf("online backup: page count: %w", err)
+	}
+	var complete bool
+	err = conn.Raw(func(driverConn any) error {
+		source, ok := driverConn.(backupConn)
+		if !ok {
+			return fmt.Errorf("online backup: driver connection %T does not support backups", driverConn)
+		}
+		backup, err := source.NewBackup(destination)
+		if err != nil {
+			return fmt.Errorf("online backup: start: %w", err)
+		}
+		complete, err = copyPages(ctx, backup, backupMaxPasses*(pages/backupPagesPerStep+1))
+		return err
+	})
+	return complete, err
+}
+
+// copyPages steps backup until it is done or maxSteps steps have run,
+// pausing between steps, and finishes it either way. It reports whether the
+// copy completed.
+func copyPages(ctx context.Context, backup backupStepper, maxSteps int) (bool, error) {
+	for step := 0; ; step++ {
+		if step == maxSteps {
+			return false, backup.Finish()
+		}
+		more, err := backup.Step(backupPagesPerStep)
+		if err != nil {
+			return false, errors.Join(fmt.Errorf("online backup: step: %w", err), backup.Finish())
+		}
+		if !more {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return false, errors.Join(ctx.Err(), backup.Finish())
+		case <-time.After(backupStepPause):
+		}
+	}
+	if err := backup.Finish(); err != nil {
+		return false, fmt.Errorf("online backup: finish: %w", err)
+	}
+	return true, nil
+}
+
+// publishBackup writes a copy through copyTo into a temporary sibling,
+// verifies it, and publishes it at destination.
+func publishBackup(ctx context.Context, db *sql.DB, destination string, opts BackupOptions, copyTo func(path string) error) error {
 	if db == nil {
 		return fmt.Errorf("backup: nil db")
 	}
@@ -308,7 +526,7 @@ func Backup(ctx context.Context, db *sql.DB, destination string, opts BackupOpti
 	temporary.Close()
 	os.Remove(tempPath)
 	defer os.Remove(tempPath)
-	if err := VacuumInto(ctx, db, tempPath); err != nil {
+	if err := copyTo(tempPath); err != nil {
 		return fmt.Errorf("backup: %w", err)
 	}
 	checkDB, err := OpenImmutable(ctx, tempPath)
@@ -1,10 +1,13 @@
 package sqlite
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"errors"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -122,6 +125,107 @@ func TestVacuumIntoAndBackup(t *testing.T) {
 	}
 }
 
+func TestOnlineBackupAndBackupToWriter(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	db, err := Open(filepath.Join(dir, "source.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE item (value TEXT); INSERT INTO item VALUES ('copied')"); err != nil {
+		t.Fatal(err)
+	}
+	backup := filepath.Join(dir, "online.db")
+	if err := OnlineBackup(ctx, db, backup, BackupOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	assertCopiedRow(t, backup)
+	if err := OnlineBackup(ctx, db, backup, BackupOptions{}); err == nil {
+		t.Fatal("expected existing destination error")
+	}
+
+	var buf bytes.Buffer
+	if err := BackupToWriter(ctx, db, &buf); err != nil {
+		t.Fatal(err)
+	}
+	streamed := filepath.Join(dir, "streamed.db")
+	if err := os.WriteFile(streamed, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	assertCopiedRow(t, streamed)
+
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := BackupToWriter(cancelled, db, &buf); !errors.Is(err, context.Canceled) {
+		t.Fatalf("cancelled backup error = %v", err)
+	}
+}
+
+// restartingBackup never finishes, like a backup whose source is written
+// by another connection between every step.
+type restartingBackup struct {
+	steps    int
+	finished bool
+}
+
+func (b *restartingBackup) Step(int32) (bool, error) {
+	b.steps++
+	return true, nil
+}
+
+func (b *restartingBackup) Finish() error {
+	b.finished = true
+	return nil
+}
+
+func TestCopyPagesStopsRestartingBackup(t *testing.T) {
+	backup := &restartingBackup{}
+	complete, err := copyPages(context.Background(), backup, 3)
+	if err != nil || complete {
+		t.Fatalf("copyPages() = %v, %v, want incomplete without error", complete, err)
+	}
+	if backup.steps != 3 || !backup.finished {
+		t.Fatalf("steps = %d, finished = %v, want 3 steps then Finish", backup.steps, backup.finished)
+	}
+}
+
+func TestScheduleBackups(t *testing.T) {
+	dir := t.TempDir()
+	cfg := DefaultConfig(filepath.Join(dir, "source.db"))
+	cfg.BackupPath = filepath.Join(dir, "scheduled.db")
+	cfg.BackupInterval = 10 * time.Millisecond
+	db, err := OpenWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE item (value TEXT); INSERT INTO item VALUES ('copied')"); err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- ScheduleBackups(ctx, db, cfg) }()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(cfg.BackupPath); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("scheduled backup was not written")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	assertCopiedRow(t, cfg.BackupPath)
+	if err := ScheduleBackups(context.Background(), db, Config{}); err == nil {
+		t.Fatal("expected missing schedule error")
+	}
+}
+
 func assertCopiedRow(t *testing.T, path string) {
 	t.Helper()
 	db, err := OpenImmutable(context.Background(), path)
@@ -153,6 +257,111 @@ func TestWALCheckpoint(t *testing.T) {
 	}
 }
 
+func TestWALCheckpointShrinksWALAndAutoCheckpoint(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "wal.db")
+	cfg := DefaultConfig(path)
+	cfg.AutoCheckpoint = 10_000
+	db, err := OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var frames int
+	if err := db.QueryRow("PRAGMA wal_autocheckpoint").Scan(&frames); err != nil || frames != cfg.AutoCheckpoint {
+		t.Fatalf("wal_autocheckpoint=%d err=%v", frames, err)
+	}
+	if _, err := db.Exec("CREATE TABLE item (value TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		if _, err := db.Exec("INSERT INTO item VALUES (?)", strings.Repeat("x", 512)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	before, err := Inspect(ctx, db, path)
+	if err != nil || before.WALBytes == nil || *before.WALBytes == 0 {
+		t.Fatalf("before=%+v err=%v", before, err)
+	}
+	result, err := WALCheckpoint(ctx, db, WALCheckpointModeTruncate)
+	if err != nil || result.LogFrames < 0 || result.CheckpointedFrames < 0 {
+		t.Fatalf("result=%+v err=%v", result, err)
+	}
+	after, err := Inspect(ctx, db, path)
+	if err != nil || after.WALBytes == nil || *after.WALBytes >= *before.WALBytes {
+		t.Fatalf("after=%+v before=%d err=%v", after, *before.WALBytes, err)
+	}
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVacuumWithResultShrinksDatabase(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "vacuum.db")
+	cfg := DefaultConfig(path)
+	cfg.WALMode = false
+	db, err := OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("CREATE TABLE item (value TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 200; i++ {
+		if _, err := db.Exec("INSERT INTO item VALUES (?)", strings.Repeat("x", 1024)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := db.Exec("DELETE FROM item"); err != nil {
+		t.Fatal(err)
+	}
+	sizeBefore := fileSize(t, path)
+	result, err := VacuumWithResult(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.PagesAfter >= result.PagesBefore {
+		t.Fatalf("result=%+v, want fewer pages", result)
+	}
+	if sizeAfter := fileSize(t, path); sizeAfter >= sizeBefore {
+		t.Fatalf("file size %d -> %d, want shrink", sizeBefore, sizeAfter)
+	}
+
+	copied, err := VacuumIntoWithResult(ctx, db, filepath.Join(dir, "copy.db"))
+	if err != nil || copied.PagesAfter != result.PagesAfter {
+		t.Fatalf("copied=%+v err=%v", copied, err)
+	}
+}
+
+func TestAutoVacuumPragma(t *testing.T) {
+	cfg := DefaultConfig(filepath.Join(t.TempDir(), "auto.db"))
+	cfg.AutoVacuum = AutoVacuumFull
+	db, err := OpenWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	var mode int
+	if err := db.QueryRow("PRAGMA auto_vacuum").Scan(&mode); err != nil || mode != 1 {
+		t.Fatalf("auto_vacuum=%d err=%v", mode, err)
+	}
+	cfg.AutoVacuum = "SOMETIMES"
+	if _, err := ResolveConfig(cfg); err == nil {
+		t.Fatal("expected invalid auto vacuum mode")
+	}
+}
+
+func fileSize(t *testing.T, path string) int64 {
+	t.Helper()
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return info.Size()
+}
+
 func TestOperationNilInputs(t *testing.T) {
 	ctx := context.Background()
 	if _, err := QuickCheck(ctx, nil); err == nil {
@@ -173,4 +382,13 @@ func TestOperationNilInputs(t *testing.T) {
 	if err := VacuumInto(ctx, nil, ""); err == nil {
 		t.Fatal("vacuum nil")
 	}
+	if _, err := VacuumWithResult(ctx, nil); err == nil {
+		t.Fatal("vacuum with result nil")
+	}
+	if err := OnlineBackup(ctx, nil, "x", BackupOptions{}); err == nil {
+		t.Fatal("online backup nil")
+	}
+	if err := BackupToWriter(ctx, nil, nil); err == nil {
+		t.Fatal("backup to writer nil")
+	}
 }
@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"log/slog"
+	"time"
+
+	moderncsqlite "modernc.org/sqlite"
+)
+
+// slowQueryConnector opens modernc.org/sqlite connections that log statements
+// running longer than threshold.
+type slowQueryConnector struct {
+	dsn       string
+	threshold time.Duration
+	logArgs   bool
+	logger    *slog.Logger
+}
+
+func newSlowQueryConnector(dsn string, cfg Config) *slowQueryConnector {
+	logger := cfg.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &slowQueryConnector{dsn: dsn, threshold: cfg.SlowQueryThreshold, logArgs: cfg.SlowQueryLogArgs, logger: logger}
+}
+
+func (c *slowQueryConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &slowQueryConn{Conn: conn, connector: c}, nil
+}
+
+func (c *slowQueryConnector) Driver() driver.Driver {
+	return &moderncsqlite.Driver{}
+}
+
+// slowQueryConn times ExecContext and QueryContext on the wrapped connection.
+// It forwards the optional interfaces database/sql and this package rely on,
+// including session reset and validation for pooled connections, argument
+// checking, and NewBackup for OnlineBackup.
+type slowQueryConn struct {
+	driver.Conn
+	connector *slowQueryConnector
+}
+
+var (
+	_ driver.ExecerContext      = (*slowQueryConn)(nil)
+	_ driver.QueryerContext     = (*slowQueryConn)(nil)
+	_ driver.ConnBeginTx        = (*slowQueryConn)(nil)
+	_ driver.ConnPrepareContext = (*slowQueryConn)(nil)
+	_ driver.SessionResetter    = (*slowQueryConn)(nil)
+	_ driver.Validator          = (*slowQueryConn)(nil)
+	_ driver.NamedValueChecker  = (*slowQueryConn)(nil)
+	_ backupConn                = (*slowQueryConn)(nil)
+)
+
+func (c *slowQueryConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	result, err := execer.ExecContext(ctx, query, args)
+	c.observe(ctx, query, args, time.Since(start))
+	return result, err
+}
+
+// QueryContext measures the time to the first row, which covers planning and
+// any work SQLite must finish before it can return a result.
+func (c *slowQueryConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	c.observe(ctx, query, args, time.Since(start))
+	return rows, err
+}
+
+func (c *slowQueryConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin() //nolint:staticcheck // fallback for drivers without BeginTx
+}
+
+func (c *slowQueryConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return preparer.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *slowQueryConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *slowQueryConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *slowQueryConn) CheckNamedValue(value *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(value)
+	}
+	return driver.ErrSkip
+}
+
+func (c *slowQueryConn) NewBackup(dstURI string) (*moderncsqlite.Backup, error) {
+	source, ok := c.Conn.(backupConn)
+	if !ok {
+		return nil, fmt.Errorf("driver connection %T does not support backups", c.Conn)
+	}
+	return source.NewBackup(dstURI)
+}
+
+func (c *slowQueryConn) observe(ctx context.Context, query string, args []driver.NamedValue, elapsed time.Duration) {
+	if elapsed < c.connector.threshold {
+		return
+	}
+	attrs := []any{"query", query, "duration", elapsed, "arg_count", len(args)}
+	if c.connector.logArgs {
+		values := make([]any, len(args))
+		for i, arg := range args {
+			values[i] = arg.Value
+		}
+		attrs = append(attrs, "args", values)
+	}
+	c.connector.logger.WarnContext(ctx, "slow query", attrs...)
+}
@@ -0,0 +1,109 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+const slowCountQuery = `WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c WHERE x < ?) SELECT count(*) FROM c`
+
+func TestSlowQueryThresholdLogsWarning(t *testing.T) {
+	for _, logArgs := range []bool{false, true} {
+		buf := runSlowQuery(t, logArgs)
+		checkSlowQueryLog(t, buf, logArgs)
+	}
+}
+
+func runSlowQuery(t *testing.T, logArgs bool) *bytes.Buffer {
+	t.Helper()
+	ctx := context.Background()
+	var buf bytes.Buffer
+	cfg := DefaultConfig(filepath.Join(t.TempDir(), "slow.db"))
+	cfg.SlowQueryThreshold = time.Millisecond
+	cfg.SlowQueryLogArgs = logArgs
+	cfg.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	db, err := OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig() error = %v", err)
+	}
+	defer db.Close()
+
+	var count int
+	if err := db.QueryRowContext(ctx, slowCountQuery, 2_000_000).Scan(&count); err != nil {
+		t.Fatalf("slow query error = %v", err)
+	}
+	if count != 2_000_000 {
+		t.Fatalf("count = %d, want 2000000", count)
+	}
+	return &buf
+}
+
+func checkSlowQueryLog(t *testing.T, buf *bytes.Buffer, logArgs bool) {
+	t.Helper()
+	var found bool
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record struct {
+			Level    string
+			Msg      string
+			Query    string
+			Duration int64
+			ArgCount int `json:"arg_count"`
+			Args     []any
+		}
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode log line %q: %v", line, err)
+		}
+		if record.Msg != "slow query" || record.Query != slowCountQuery {
+			continue
+		}
+		found = true
+		if record.Level != "WARN" {
+			t.Errorf("level = %q, want WARN", record.Level)
+		}
+		if time.Duration(record.Duration) < time.Millisecond {
+			t.Errorf("duration = %v, want at least 1ms", time.Duration(record.Duration))
+		}
+		if record.ArgCount != 1 {
+			t.Errorf("arg_count = %d, want 1", record.ArgCount)
+		}
+		if logArgs && (len(record.Args) != 1 || record.Args[0] != float64(2_000_000)) {
+			t.Errorf("args = %v, want [2000000]", record.Args)
+		}
+		if !logArgs && record.Args != nil {
+			t.Errorf("args = %v logged without SlowQueryLogArgs", record.Args)
+		}
+	}
+	if !found {
+		t.Fatalf("no slow query warning logged:\n%s", buf.String())
+	}
+}
+
+func TestSlowQueryThresholdKeepsFastQueriesQuiet(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+	dir := t.TempDir()
+	cfg := DefaultConfig(filepath.Join(dir, "fast.db"))
+	cfg.SlowQueryThreshold = time.Hour
+	cfg.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	db, err := OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig() error = %v", err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE note (body TEXT)`); err != nil {
+		t.Fatalf("create table: %v", err)
+	}
+	if err := OnlineBackup(ctx, db, filepath.Join(dir, "backup.db"), BackupOptions{}); err != nil {
+		t.Fatalf("OnlineBackup through slow-query wrapper: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("unexpected log output:\n%s", buf.String())
+	}
+}
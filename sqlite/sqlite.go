@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"net/url"
 	"path/filepath"
 	"strings"
@@ -43,6 +44,40 @@ const (
 	SynchronousExtra  SynchronousMode = "EXTRA"
 )
 
+type AutoVacuumMode string
+
+const (
+	AutoVacuumNone        AutoVacuumMode = "NONE"
+	AutoVacuumFull        AutoVacuumMode = "FULL"
+	AutoVacuumIncremental AutoVacuumMode = "INCREMENTAL"
+)
+
+// TempStoreMode sets where SQLite keeps temporary tables and indices.
+type TempStoreMode string
+
+const (
+	TempStoreDefault TempStoreMode = "DEFAULT"
+	TempStoreFile    TempStoreMode = "FILE"
+	TempStoreMemory  TempStoreMode = "MEMORY"
+)
+
+// TxLockMode selects how BEGIN acquires locks. SQLite has no isolation
+// levels beyond serializable; the lock mode decides whether a transaction
+// takes the write lock up front or upgrades to it on first write.
+type TxLockMode string
+
+const (
+	// TxLockDeferred takes no lock until the first read or write. A
+	// transaction that reads and later writes may fail with SQLITE_BUSY when
+	// another connection committed in between.
+	TxLockDeferred TxLockMode = "deferred"
+	// TxLockImmediate takes the write lock at BEGIN, waiting up to
+	// BusyTimeout, so a read-modify-write cannot lose a concurrent update.
+	TxLockImmediate TxLockMode = "immediate"
+	// TxLockExclusive also blocks readers outside WAL mode.
+	TxLockExclusive TxLockMode = "exclusive"
+)
+
 const (
 	DefaultBusyTimeout     = 5 * time.Second
 	DefaultCacheSizeKB     = 2_000
@@ -60,6 +95,7 @@ type Config struct {
 	WALMode         bool
 	JournalMode     JournalMode
 	Synchronous     SynchronousMode
+	AutoVacuum      AutoVacuumMode
 	BusyTimeout     time.Duration
 	MaxOpenConns    int
 	MaxIdleConns    int
@@ -67,18 +103,47 @@ type Config struct {
 	ForeignKeys     bool
 	CacheSizeKB     int
 	MmapSizeBytes   int64
+	// TempStore sets PRAGMA temp_store; empty uses TempStoreMemory.
+	TempStore TempStoreMode
+	// PageSize sets PRAGMA page_size in bytes, a power of two from 512 to
+	// 65536. It only takes effect when the database file is created; zero
+	// keeps SQLite's default of 4096.
+	PageSize int
+	// AutoCheckpoint sets PRAGMA wal_autocheckpoint in frames; zero keeps
+	// SQLite's default of 1000.
+	AutoCheckpoint int
+	// BackupPath and BackupInterval configure ScheduleBackups.
+	BackupPath     string
+	BackupInterval time.Duration
+	// TxLock sets how transactions begin; empty uses TxLockImmediate for
+	// read-write files and SQLite's deferred default for memory databases.
+	TxLock TxLockMode
+	// SlowQueryThreshold logs a "slow query" warning to Logger for every
+	// statement that runs at least this long; zero disables the check.
+	SlowQueryThreshold time.Duration
+	// SlowQueryLogArgs adds the statement arguments to slow-query warnings.
+	// Leave it off where arguments can carry secrets; the warning always
+	// has the argument count.
+	SlowQueryLogArgs bool
+	// Logger receives slow-query warnings; nil uses slog.Default().
+	Logger *slog.Logger
 }
 
 type EffectiveConfig struct {
-	Mode          Mode
-	BusyTimeout   time.Duration
-	ForeignKeys   bool
-	JournalMode   JournalMode
-	Synchronous   SynchronousMode
-	CacheSizeKB   int
-	MmapSizeBytes int64
-	MaxOpenConns  int
-	MaxIdleConns  int
+	Mode           Mode
+	BusyTimeout    time.Duration
+	ForeignKeys    bool
+	JournalMode    JournalMode
+	Synchronous    SynchronousMode
+	CacheSizeKB    int
+	MmapSizeBytes  int64
+	MaxOpenConns   int
+	MaxIdleConns   int
+	AutoCheckpoint int
+	AutoVacuum     AutoVacuumMode
+	TxLock         TxLockMode
+	TempStore      TempStoreMode
+	PageSize       int
 }
 
 func DefaultConfig(path string) Config {
@@ -117,16 +182,22 @@ func ResolveConfig(cfg Config) (EffectiveConfig, error) {
 	if mode != ModeReadWrite && mode != ModeReadOnly && mode != ModeImmutable && mode != ModeMemory {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: unsupported mode %q", mode)
 	}
-	if cfg.BusyTimeout < 0 || cfg.ConnMaxLifetime < 0 {
+	if cfg.BusyTimeout < 0 || cfg.ConnMaxLifetime < 0 || cfg.BackupInterval < 0 {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: durations must not be negative")
 	}
-	if cfg.MaxOpenConns < 0 || cfg.MaxIdleConns < 0 || cfg.CacheSizeKB < 0 || cfg.MmapSizeBytes < 0 {
+	if cfg.MaxOpenConns < 0 || cfg.MaxIdleConns < 0 || cfg.CacheSizeKB < 0 || cfg.MmapSizeBytes < 0 || cfg.AutoCheckpoint < 0 {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: limits must not be negative")
 	}
 	if cfg.MaxOpenConns > 0 && cfg.MaxIdleConns > cfg.MaxOpenConns {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: MaxIdleConns exceeds MaxOpenConns")
 	}
-	if !validJournalMode(cfg.JournalMode) || !validSynchronousMode(cfg.Synchronous) {
+	if cfg.BackupInterval > 0 && cfg.BackupPath == "" {
+		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: BackupInterval requires BackupPath")
+	}
+	if cfg.PageSize != 0 && (cfg.PageSize < 512 || cfg.PageSize > 65536 || cfg.PageSize&(cfg.PageSize-1) != 0) {
+		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: PageSize must be a power of two from 512 to 65536")
+	}
+	if !validJournalMode(cfg.JournalMode) || !validSynchronousMode(cfg.Synchronous) || !validAutoVacuumMode(cfg.AutoVacuum) || !validTxLockMode(cfg.TxLock) || !validTempStoreMode(cfg.TempStore) {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: unsupported pragma value")
 	}
 	isMemory := cfg.Path == ":memory:" || strings.HasPrefix(cfg.Path, "file:") && strings.Contains(cfg.Path, "mode=memory")
@@ -140,7 +211,7 @@ func ResolveConfig(cfg Config) (EffectiveConfig, error) {
 		}
 		journal = JournalModeWAL
 	}
-	if (mode == ModeReadOnly || mode == ModeImmutable) && (journal != "" || cfg.Synchronous != "") {
+	if (mode == ModeReadOnly || mode == ModeImmutable) && (journal != "" || cfg.Synchronous != "" || cfg.AutoVacuum != "" || cfg.TxLock != "" || cfg.PageSize != 0) {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: read-only modes cannot set write pragmas")
 	}
 	if mode == ModeMemory && journal == JournalModeWAL {
@@ -164,7 +235,15 @@ func ResolveConfig(cfg Config) (EffectiveConfig, error) {
 	if cfg.Path == ":memory:" && (open != 1 || idle != 1) {
 		return EffectiveConfig{}, fmt.Errorf("resolve sqlite config: :memory: requires one open and idle connection")
 	}
-	return EffectiveConfig{mode, cfg.BusyTimeout, cfg.ForeignKeys, journal, syncMode, cache, cfg.MmapSizeBytes, open, idle}, nil
+	txLock := cfg.TxLock
+	if txLock == "" && mode == ModeReadWrite {
+		txLock = TxLockImmediate
+	}
+	tempStore := cfg.TempStore
+	if tempStore == "" {
+		tempStore = TempStoreMemory
+	}
+	return EffectiveConfig{mode, cfg.BusyTimeout, cfg.ForeignKeys, journal, syncMode, cache, cfg.MmapSizeBytes, open, idle, cfg.AutoCheckpoint, cfg.AutoVacuum, txLock, tempStore, cfg.PageSize}, nil
 }
 
 func Open(path string) (*sql.DB, error) { return OpenContext(context.Background(), path) }
@@ -187,8 +266,16 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*sql.DB, error) {
 	if err != nil {
 		return nil, err
 	}
-	db, err := sql.Open("sqlite", buildEffectiveDSN(cfg, effective))
-	if err != nil {
+	if effective.Mode == ModeReadWrite && effective.PageSize > 0 {
+		if err := initPageSize(ctx, cfg.Path, effective.PageSize); err != nil {
+			return nil, err
+		}
+	}
+	dsn := buildEffectiveDSN(cfg, effective)
+	var db *sql.DB
+	if cfg.SlowQueryThreshold > 0 {
+		db = sql.OpenDB(newSlowQueryConnector(dsn, cfg))
+	} else if db, err = sql.Open("sqlite", dsn); err != nil {
 		return nil, fmt.Errorf("open sqlite: %w", err)
 	}
 	db.SetMaxOpenConns(effective.MaxOpenConns)
@@ -203,6 +290,30 @@ func OpenWithConfig(ctx context.Context, cfg Config) (*sql.DB, error) {
 	return db, nil
 }
 
+// initPageSize creates the database at path with pageSize before WAL mode
+// fixes the default. It opens path through the same file: URI as the main
+// connection and checks page_count instead of the file size, so escaped
+// names address the same file and open errors are returned. Databases that
+// already have pages are left unchanged.
+func initPageSize(ctx context.Context, path string, pageSize int) error {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=page_size(%d)", escapeSQLitePath(path), pageSize))
+	if err != nil {
+		return fmt.Errorf("open sqlite: %w", err)
+	}
+	defer db.Close()
+	var pages int
+	if err := db.QueryRowContext(ctx, "PRAGMA page_count").Scan(&pages); err != nil {
+		return fmt.Errorf("read sqlite page count: %w", err)
+	}
+	if pages > 0 {
+		return nil
+	}
+	if _, err := db.ExecContext(ctx, "VACUUM"); err != nil {
+		return fmt.Errorf("set sqlite page size: %w", err)
+	}
+	return nil
+}
+
 func buildDSN(cfg Config) string {
 	effective, err := ResolveConfig(cfg)
 	if err != nil {
@@ -212,8 +323,8 @@ func buildDSN(cfg Config) string {
 }
 func buildEffectiveDSN(cfg Config, e EffectiveConfig) string {
 	var p []string
-	if e.Mode == ModeReadWrite {
-		p = append(p, "_txlock=immediate")
+	if e.TxLock != "" {
+		p = append(p, "_txlock="+string(e.TxLock))
 	}
 	if e.Mode == ModeReadOnly || e.Mode == ModeImmutable {
 		p = append(p, "mode=ro")
@@ -227,17 +338,28 @@ func buildEffectiveDSN(cfg Config, e EffectiveConfig) string {
 	if e.ForeignKeys {
 		p = append(p, "_pragma=foreign_keys(1)")
 	}
+	// The driver runs pragmas in lexical order, after journal_mode, so this
+	// only sizes in-memory databases; OpenWithConfig sizes new files first.
+	if e.PageSize > 0 {
+		p = append(p, fmt.Sprintf("_pragma=page_size(%d)", e.PageSize))
+	}
+	if e.AutoVacuum != "" {
+		p = append(p, fmt.Sprintf("_pragma=auto_vacuum(%s)", e.AutoVacuum))
+	}
 	if e.JournalMode != "" {
 		p = append(p, fmt.Sprintf("_pragma=journal_mode(%s)", e.JournalMode))
 	}
 	if e.Synchronous != "" {
 		p = append(p, fmt.Sprintf("_pragma=synchronous(%s)", e.Synchronous))
 	}
+	if e.AutoCheckpoint > 0 {
+		p = append(p, fmt.Sprintf("_pragma=wal_autocheckpoint(%d)", e.AutoCheckpoint))
+	}
 	p = append(p, fmt.Sprintf("_pragma=cache_size(-%d)", e.CacheSizeKB))
 	if e.MmapSizeBytes > 0 {
 		p = append(p, fmt.Sprintf("_pragma=mmap_size(%d)", e.MmapSizeBytes))
 	}
-	p = append(p, "_pragma=temp_store(MEMORY)")
+	p = append(p, fmt.Sprintf("_pragma=temp_store(%s)", e.TempStore))
 	if e.Mode == ModeMemory && strings.HasPrefix(cfg.Path, "file:") {
 		sep := "?"
 		if strings.Contains(cfg.Path, "?") {
@@ -270,6 +392,27 @@ func validJournalMode(v JournalMode) bool {
 	}
 	return false
 }
+func validTxLockMode(v TxLockMode) bool {
+	switch v {
+	case "", TxLockDeferred, TxLockImmediate, TxLockExclusive:
+		return true
+	}
+	return false
+}
+func validTempStoreMode(v TempStoreMode) bool {
+	switch v {
+	case "", TempStoreDefault, TempStoreFile, TempStoreMemory:
+		return true
+	}
+	return false
+}
+func validAutoVacuumMode(v AutoVacuumMode) bool {
+	switch v {
+	case "", AutoVacuumNone, AutoVacuumFull, AutoVacuumIncremental:
+		return true
+	}
+	return false
+}
 func validSynchronousMode(v SynchronousMode) bool {
 	switch v {
 	case "", SynchronousOff, SynchronousNormal, SynchronousFull, SynchronousExtra:
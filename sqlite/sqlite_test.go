@@ -3,6 +3,8 @@ package sqlite
 import (
 	"context"
 	"database/sql"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -136,6 +138,64 @@ func TestPerConnectionPragmas(t *testing.T) {
 	}
 }
 
+func TestCacheAndPagePragmasApply(t *testing.T) {
+	cfg := DefaultConfig(filepath.Join(t.TempDir(), "tuned.db"))
+	cfg.CacheSizeKB = 4096
+	cfg.PageSize = 8192
+	cfg.TempStore = TempStoreFile
+	db, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+
+	for pragma, want := range map[string]int{"cache_size": -4096, "page_size": 8192, "temp_store": 1} {
+		var got int
+		if err := db.QueryRowContext(t.Context(), "PRAGMA "+pragma).Scan(&got); err != nil {
+			t.Fatalf("PRAGMA %s: %v", pragma, err)
+		}
+		if got != want {
+			t.Errorf("PRAGMA %s = %d, want %d", pragma, got, want)
+		}
+	}
+}
+
+func TestPageSizeOnlySizesNewDatabases(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app #1?.db")
+	cfg := DefaultConfig(path)
+	cfg.PageSize = 8192
+	db, err := OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	if _, err := db.ExecContext(t.Context(), "CREATE TABLE item (id INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	cfg.PageSize = 1024
+	db, err = OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer db.Close()
+	var size int
+	if err := db.QueryRowContext(t.Context(), "PRAGMA page_size").Scan(&size); err != nil || size != 8192 {
+		t.Fatalf("page_size = %d, err = %v, want 8192 kept", size, err)
+	}
+
+	blocker := filepath.Join(dir, "file")
+	if err := os.WriteFile(blocker, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg = DefaultConfig(filepath.Join(blocker, "app.db"))
+	cfg.PageSize = 8192
+	if _, err := OpenWithConfig(t.Context(), cfg); err == nil {
+		t.Fatal("open under a regular file succeeded")
+	}
+}
+
 func TestResolveConfigProfilesAndValidation(t *testing.T) {
 	t.Run("read heavy", func(t *testing.T) {
 		cfg := ReadHeavyConfig("app.db")
@@ -157,6 +217,68 @@ func TestResolveConfigProfilesAndValidation(t *testing.T) {
 		}
 	})
 
+	t.Run("auto checkpoint pragma", func(t *testing.T) {
+		cfg := DefaultConfig("app.db")
+		cfg.AutoCheckpoint = 500
+		if dsn := buildDSN(cfg); !strings.Contains(dsn, "_pragma=wal_autocheckpoint(500)") {
+			t.Fatalf("DSN %q missing wal_autocheckpoint", dsn)
+		}
+		cfg.AutoCheckpoint = -1
+		if _, err := ResolveConfig(cfg); err == nil {
+			t.Fatal("expected negative AutoCheckpoint error")
+		}
+	})
+
+	t.Run("reject backup interval without path", func(t *testing.T) {
+		cfg := DefaultConfig("app.db")
+		cfg.BackupInterval = time.Hour
+		if _, err := ResolveConfig(cfg); err == nil {
+			t.Fatal("expected backup validation error")
+		}
+	})
+
+	t.Run("tx lock", func(t *testing.T) {
+		cfg := DefaultConfig("app.db")
+		cfg.TxLock = TxLockDeferred
+		if dsn := buildDSN(cfg); !strings.Contains(dsn, "_txlock=deferred") || strings.Contains(dsn, "_txlock=immediate") {
+			t.Fatalf("DSN %q does not use deferred txlock", dsn)
+		}
+		cfg.TxLock = "lazy"
+		if _, err := ResolveConfig(cfg); err == nil {
+			t.Fatal("expected unsupported TxLock error")
+		}
+		readOnly := ReadOnlyConfig()
+		readOnly.Path = "app.db"
+		readOnly.TxLock = TxLockImmediate
+		if _, err := ResolveConfig(readOnly); err == nil {
+			t.Fatal("expected read-only TxLock error")
+		}
+	})
+
+	t.Run("temp store and page size", func(t *testing.T) {
+		cfg := DefaultConfig("app.db")
+		cfg.TempStore = TempStoreFile
+		cfg.PageSize = 8192
+		dsn := buildDSN(cfg)
+		if !strings.Contains(dsn, "_pragma=temp_store(FILE)") || !strings.Contains(dsn, "_pragma=page_size(8192)") {
+			t.Fatalf("DSN %q missing temp_store or page_size", dsn)
+		}
+		if strings.Index(dsn, "page_size") > strings.Index(dsn, "journal_mode") {
+			t.Fatalf("DSN %q sets page_size after journal_mode", dsn)
+		}
+		for _, size := range []int{256, 1000, 131072} {
+			cfg.PageSize = size
+			if _, err := ResolveConfig(cfg); err == nil {
+				t.Fatalf("PageSize %d accepted", size)
+			}
+		}
+		cfg.PageSize = 0
+		cfg.TempStore = "disk"
+		if _, err := ResolveConfig(cfg); err == nil {
+			t.Fatal("expected unsupported TempStore error")
+		}
+	})
+
 	t.Run("reject wal in memory", func(t *testing.T) {
 		cfg := DefaultConfig(":memory:")
 		cfg.WALMode = true
@@ -207,3 +329,89 @@ func TestTransactionWithOptionsRejectsNilInputs(t *testing.T) {
 		t.Fatal("expected nil callback error")
 	}
 }
+
+// openCounter opens a two-connection WAL database holding one counter row.
+func openCounter(t *testing.T, lock TxLockMode) *sql.DB {
+	t.Helper()
+	cfg := DefaultConfig(filepath.Join(t.TempDir(), "counter.db"))
+	cfg.TxLock = lock
+	cfg.MaxOpenConns = 2
+	cfg.MaxIdleConns = 2
+	cfg.BusyTimeout = time.Second
+	db, err := OpenWithConfig(context.Background(), cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	if _, err := db.Exec(`CREATE TABLE counter (n INTEGER NOT NULL); INSERT INTO counter VALUES (0)`); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func increment(ctx context.Context, tx *sql.Tx) error {
+	var n int
+	if err := tx.QueryRowContext(ctx, `SELECT n FROM counter`).Scan(&n); err != nil {
+		return err
+	}
+	_, err := tx.ExecContext(ctx, `UPDATE counter SET n = ?`, n+1)
+	return err
+}
+
+func TestTxLockDeferredFailsStaleUpgrade(t *testing.T) {
+	ctx := context.Background()
+	db := openCounter(t, TxLockDeferred)
+
+	first, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer second.Rollback()
+	var n int
+	if err := second.QueryRowContext(ctx, `SELECT n FROM counter`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if err := increment(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.ExecContext(ctx, `UPDATE counter SET n = ?`, n+1); !IsBusy(err) {
+		t.Fatalf("stale deferred write error = %v, want SQLITE_BUSY", err)
+	}
+}
+
+func TestTxLockImmediateSerializesWriters(t *testing.T) {
+	ctx := context.Background()
+	db := openCounter(t, TxLockImmediate)
+
+	first, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- Transaction(ctx, db, func(tx *sql.Tx) error { return increment(ctx, tx) })
+	}()
+	if err := increment(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if err := first.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("second immediate transaction error = %v", err)
+	}
+	var n int
+	if err := db.QueryRowContext(ctx, `SELECT n FROM counter`).Scan(&n); err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Fatalf("counter = %d, want 2", n)
+	}
+}
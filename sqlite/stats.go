@@ -0,0 +1,94 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+// PoolStats is a JSON-encodable snapshot of sql.DBStats.
+type PoolStats struct {
+	MaxOpenConnections int           `json:"max_open_connections"`
+	OpenConnections    int           `json:"open_connections"`
+	InUse              int           `json:"in_use"`
+	Idle               int           `json:"idle"`
+	WaitCount          int64         `json:"wait_count"`
+	WaitDuration       time.Duration `json:"wait_duration_ns"`
+	MaxIdleClosed      int64         `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64         `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64         `json:"max_lifetime_closed"`
+}
+
+// ReadPoolStats returns a snapshot of db's connection pool counters. A nil db
+// reports zero values.
+func ReadPoolStats(db *sql.DB) PoolStats {
+	if db == nil {
+		return PoolStats{}
+	}
+	stat := db.Stats()
+	return PoolStats{
+		MaxOpenConnections: stat.MaxOpenConnections,
+		OpenConnections:    stat.OpenConnections,
+		InUse:              stat.InUse,
+		Idle:               stat.Idle,
+		WaitCount:          stat.WaitCount,
+		WaitDuration:       stat.WaitDuration,
+		MaxIdleClosed:      stat.MaxIdleClosed,
+		MaxIdleTimeClosed:  stat.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  stat.MaxLifetimeClosed,
+	}
+}
+
+// StatsHandler serves ReadPoolStats(db) as JSON. Mount it on an internal route;
+// the counters reveal load but no database contents.
+//
+// Example:
+//
+//	router.Handle("/debug/sqlite", sqlite.StatsHandler(db))
+func StatsHandler(db *sql.DB) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		_ = json.NewEncoder(w).Encode(ReadPoolStats(db))
+	})
+}
+
+// LogPoolStats logs ReadPoolStats(db) to logger every interval as an
+// "sqlite pool stats" message. It blocks until ctx is cancelled, like
+// ScheduleBackups.
+//
+// Example:
+//
+//	go sqlite.LogPoolStats(ctx, db, slog.Default(), time.Minute)
+func LogPoolStats(ctx context.Context, db *sql.DB, logger *slog.Logger, interval time.Duration) error {
+	if db == nil {
+		return fmt.Errorf("log pool stats: nil db")
+	}
+	if interval <= 0 {
+		return fmt.Errorf("log pool stats: interval must be positive")
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			stats := ReadPoolStats(db)
+			logger.LogAttrs(ctx, slog.LevelInfo, "sqlite pool stats",
+				slog.Int("open_connections", stats.OpenConnections),
+				slog.Int("in_use", stats.InUse),
+				slog.Int("idle", stats.Idle),
+				slog.Int64("wait_count", stats.WaitCount),
+				slog.Duration("wait_duration", stats.WaitDuration),
+			)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatsHandlerServesJSON(t *testing.T) {
+	db, err := InMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	conn, err := db.Conn(t.Context())
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	StatsHandler(db).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/sqlite", nil))
+	conn.Close()
+
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Fatalf("Content-Type = %q, want application/json", got)
+	}
+	var stats map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &stats); err != nil {
+		t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+	}
+	for _, field := range []string{"open_connections", "in_use", "wait_count"} {
+		if _, ok := stats[field]; !ok {
+			t.Fatalf("stats %v missing %q", stats, field)
+		}
+	}
+	if stats["in_use"] != float64(1) {
+		t.Fatalf("in_use = %v, want 1 while a connection is held", stats["in_use"])
+	}
+	if got := ReadPoolStats(nil); got != (PoolStats{}) {
+		t.Fatalf("ReadPoolStats(nil) = %+v, want zero values", got)
+	}
+}
+
+func TestLogPoolStats(t *testing.T) {
+	db, err := InMemory()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, nil))
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	if err := LogPoolStats(ctx, db, logger, 10*time.Millisecond); err != nil {
+		t.Fatalf("LogPoolStats: %v", err)
+	}
+	if !strings.Contains(buf.String(), `msg="sqlite pool stats"`) || !strings.Contains(buf.String(), "open_connections=") {
+		t.Fatalf("log output = %q", buf.String())
+	}
+	if err := LogPoolStats(t.Context(), db, logger, 0); err == nil {
+		t.Fatal("zero interval accepted")
+	}
+}
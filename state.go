@@ -1,8 +1,6 @@
 package gokart
 
 import (
-	"encoding/json"
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -10,9 +8,16 @@ import (
 
 // SaveState saves typed state under the platform user config directory.
 //
-// The file is written as indented JSON for human readability.
+// The file is written as indented JSON for human readability, whatever its
+// extension. Use SaveStateFormat for YAML or TOML.
 // Directory is created with 0755, files with 0600 permissions.
 //
+// The state is written to a temporary file in the same directory, synced,
+// and renamed over the target, so a crash or failed write leaves either the
+// previous or the new state, never a partial file. On Windows the rename is
+// still atomic but the directory entry cannot be flushed, so the newest save
+// may be lost on power failure.
+//
 // Example:
 //
 //	type AppState struct {
@@ -24,16 +29,16 @@ import (
 //	    WindowSize: 1024,
 //	})
 func SaveState[T any](appName, filename string, data T) error {
+	return SaveStateFormat(appName, filename, data, StateFormatJSON)
+}
+
+// writeState atomically publishes encoded state content.
+func writeState(appName, filename string, content []byte) error {
 	dir, err := stateDir(appName)
 	if err != nil {
 		return fmt.Errorf("get config dir: %w", err)
 	}
 
-	content, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return fmt.Errorf("marshal state: %w", err)
-	}
-
 	path := filepath.Join(dir, filename)
 	if err := atomicWriteFile(path, content, 0o600); err != nil {
 		return fmt.Errorf("publish state file: %w", err)
@@ -44,6 +49,7 @@ func SaveState[T any](appName, filename string, data T) error {
 
 // LoadState loads typed state from the platform user config directory.
 //
+// The file is decoded as JSON. Use LoadStateFormat for YAML or TOML.
 // Returns zero value and os.ErrNotExist if the file doesn't exist.
 // This allows callers to distinguish between missing file and parse errors.
 //
@@ -57,23 +63,7 @@ func SaveState[T any](appName, filename string, data T) error {
 //	    return err
 //	}
 func LoadState[T any](appName, filename string) (T, error) {
-	var zero T
-
-	path := StatePath(appName, filename)
-	content, err := os.ReadFile(path)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return zero, os.ErrNotExist
-		}
-		return zero, fmt.Errorf("read state file: %w", err)
-	}
-
-	var result T
-	if err := json.Unmarshal(content, &result); err != nil {
-		return zero, fmt.Errorf("unmarshal state: %w", err)
-	}
-
-	return result, nil
+	return LoadStateFormat[T](appName, filename, StateFormatJSON)
 }
 
 // StatePath returns the full path to a state file.
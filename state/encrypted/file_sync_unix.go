@@ -0,0 +1,18 @@
+//go:build !windows
+
+package encrypted
+
+import (
+	"errors"
+	"os"
+)
+
+// syncDirectory flushes directory entries so a completed rename survives a
+// crash.
+func syncDirectory(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	return errors.Join(dir.Sync(), dir.Close())
+}
@@ -0,0 +1,8 @@
+//go:build windows
+
+package encrypted
+
+// syncDirectory is a no-op on Windows, which cannot flush a directory handle.
+func syncDirectory(string) error {
+	return nil
+}
@@ -0,0 +1,9 @@
+module github.com/dotcommander/gokart/state/encrypted
+
+go 1.26.0
+
+toolchain go1.26.3
+
+require golang.org/x/crypto v0.53.0
+
+require golang.org/x/sys v0.46.0 // indirect
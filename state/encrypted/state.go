@@ -0,0 +1,198 @@
+// Package encrypted stores gokart state files encrypted at rest with
+// AES-256-GCM. It is a separate module so that only applications that
+// encrypt state depend on golang.org/x/crypto.
+package encrypted
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// Argon2id parameters follow the second recommended option of RFC 9106.
+const (
+	keyTime    = 3
+	keyMemory  = 64 * 1024
+	keyThreads = 4
+
+	// KeySize is the length in bytes of the key SaveEncryptedState and
+	// LoadEncryptedState accept.
+	KeySize = 32
+
+	saltSize = 16
+)
+
+// DeriveStateKey derives a KeySize key from passphrase with Argon2id. The
+// salt is fixed per appName, so the same passphrase and appName always give
+// the same key; derive it once at startup, because each call costs 64 MiB
+// and noticeable CPU time. Every saved file still gets its own key through
+// the random salt SaveEncryptedState stores in it.
+//
+// Example:
+//
+//	key, err := encrypted.DeriveStateKey(os.Getenv("MYAPP_PASSPHRASE"), "myapp")
+func DeriveStateKey(passphrase, appName string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("derive state key: empty passphrase")
+	}
+	if appName == "" {
+		return nil, fmt.Errorf("derive state key: empty app name")
+	}
+	salt := sha256.Sum256([]byte("gokart/state/encrypted\x00" + appName))
+	return argon2.IDKey([]byte(passphrase), salt[:saltSize], keyTime, keyMemory, keyThreads, KeySize), nil
+}
+
+// SaveEncryptedState saves state like gokart.SaveState, as JSON sealed with
+// AES-256-GCM, at the path gokart.StatePath returns. key must be KeySize
+// bytes, from DeriveStateKey or a secret store. Every save draws a random
+// salt and derives the file key from key and that salt with HKDF-SHA256.
+// The file holds the salt, a random nonce, and the ciphertext, in that
+// order, and is published through a synced temporary file and rename.
+//
+// Example:
+//
+//	err := encrypted.SaveEncryptedState("myapp", "token.bin", key, token)
+func SaveEncryptedState[T any](appName, filename string, key []byte, data T) error {
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("generate salt: %w", err)
+	}
+	aead, err := fileCipher(key, salt)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("generate nonce: %w", err)
+	}
+	header := append(salt, nonce...)
+
+	path, err := statePath(appName, filename)
+	if err != nil {
+		return fmt.Errorf("get config dir: %w", err)
+	}
+	if err := atomicWriteFile(path, aead.Seal(header, nonce, plaintext, nil), 0o600); err != nil {
+		return fmt.Errorf("publish state file: %w", err)
+	}
+	return nil
+}
+
+// LoadEncryptedState loads state written by SaveEncryptedState with the same
+// key. Like gokart.LoadState it returns os.ErrNotExist for a missing file; a
+// wrong key or a modified file fails authentication.
+//
+// Example:
+//
+//	token, err := encrypted.LoadEncryptedState[Token]("myapp", "token.bin", key)
+func LoadEncryptedState[T any](appName, filename string, key []byte) (T, error) {
+	var zero T
+	path, err := statePath(appName, filename)
+	if err != nil {
+		return zero, fmt.Errorf("get config dir: %w", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return zero, os.ErrNotExist
+		}
+		return zero, fmt.Errorf("read state file: %w", err)
+	}
+	if len(content) < saltSize {
+		return zero, fmt.Errorf("decrypt state: file too short")
+	}
+	salt, content := content[:saltSize], content[saltSize:]
+	aead, err := fileCipher(key, salt)
+	if err != nil {
+		return zero, err
+	}
+	if len(content) < aead.NonceSize() {
+		return zero, fmt.Errorf("decrypt state: file too short")
+	}
+	nonce, ciphertext := content[:aead.NonceSize()], content[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return zero, fmt.Errorf("decrypt state: %w", err)
+	}
+
+	var result T
+	if err := json.Unmarshal(plaintext, &result); err != nil {
+		return zero, fmt.Errorf("unmarshal state: %w", err)
+	}
+	return result, nil
+}
+
+// fileCipher returns AES-256-GCM under the key HKDF derives from key and the
+// file's salt.
+func fileCipher(key, salt []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("encrypted state: key is %d bytes, want %d", len(key), KeySize)
+	}
+	fileKey, err := hkdf.Key(sha256.New, key, salt, "gokart/state/encrypted", KeySize)
+	if err != nil {
+		return nil, fmt.Errorf("derive file key: %w", err)
+	}
+	block, err := aes.NewCipher(fileKey)
+	if err != nil {
+		return nil, fmt.Errorf("create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// statePath mirrors gokart.StatePath, so both modules address the same file.
+func statePath(appName, filename string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, appName, filename), nil
+}
+
+// atomicWriteFile publishes a fully synced sibling file over path.
+func atomicWriteFile(path string, data []byte, perm os.FileMode) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("create directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".gokart-write-*")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	tmpPath := tmp.Name()
+	defer func() {
+		_ = os.Remove(tmpPath)
+	}()
+
+	if err := tmp.Chmod(perm); err != nil {
+		return errors.Join(fmt.Errorf("set temporary file permissions: %w", err), tmp.Close())
+	}
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(fmt.Errorf("write temporary file: %w", err), tmp.Close())
+	}
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(fmt.Errorf("sync temporary file: %w", err), tmp.Close())
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("close temporary file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("publish file: %w", err)
+	}
+	if err := syncDirectory(dir); err != nil {
+		return fmt.Errorf("sync directory: %w", err)
+	}
+	return nil
+}
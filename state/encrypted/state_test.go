@@ -0,0 +1,124 @@
+package encrypted_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart/state/encrypted"
+)
+
+type token struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+// useConfigDir points os.UserConfigDir at a temporary directory and returns
+// the app directory inside it.
+func useConfigDir(t *testing.T, appName string) string {
+	t.Helper()
+	root := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", root)
+	t.Setenv("HOME", root)
+	t.Setenv("AppData", root)
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		t.Fatalf("UserConfigDir: %v", err)
+	}
+	return filepath.Join(configDir, appName)
+}
+
+func TestEncryptedState_RoundTripAndWrongKey(t *testing.T) {
+	dir := useConfigDir(t, "myapp")
+	key, err := encrypted.DeriveStateKey("correct horse battery staple", "myapp")
+	if err != nil {
+		t.Fatalf("DeriveStateKey: %v", err)
+	}
+
+	original := token{Name: "oauth-token", Count: 3}
+	if err := encrypted.SaveEncryptedState("myapp", "token.bin", key, original); err != nil {
+		t.Fatalf("SaveEncryptedState: %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "token.bin"))
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if json.Valid(content) || bytes.Contains(content, []byte("oauth-token")) {
+		t.Fatalf("state file is not encrypted: %q", content)
+	}
+
+	loaded, err := encrypted.LoadEncryptedState[token]("myapp", "token.bin", key)
+	if err != nil {
+		t.Fatalf("LoadEncryptedState: %v", err)
+	}
+	if loaded != original {
+		t.Fatalf("loaded = %+v, want %+v", loaded, original)
+	}
+
+	wrong, err := encrypted.DeriveStateKey("wrong passphrase", "myapp")
+	if err != nil {
+		t.Fatalf("DeriveStateKey: %v", err)
+	}
+	if _, err := encrypted.LoadEncryptedState[token]("myapp", "token.bin", wrong); err == nil {
+		t.Fatal("LoadEncryptedState with wrong key succeeded")
+	}
+
+	if err := encrypted.SaveEncryptedState("myapp", "token.bin", key, original); err != nil {
+		t.Fatalf("SaveEncryptedState again: %v", err)
+	}
+	resaved, err := os.ReadFile(filepath.Join(dir, "token.bin"))
+	if err != nil {
+		t.Fatalf("ReadFile again: %v", err)
+	}
+	if bytes.Equal(resaved[:16], content[:16]) {
+		t.Fatal("salt header reused across saves")
+	}
+	resaved[0] ^= 0xff
+	if err := os.WriteFile(filepath.Join(dir, "token.bin"), resaved, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if _, err := encrypted.LoadEncryptedState[token]("myapp", "token.bin", key); err == nil {
+		t.Fatal("LoadEncryptedState with modified salt succeeded")
+	}
+}
+
+func TestDeriveStateKey(t *testing.T) {
+	t.Parallel()
+
+	first, err := encrypted.DeriveStateKey("secret", "myapp")
+	if err != nil {
+		t.Fatalf("DeriveStateKey: %v", err)
+	}
+	again, err := encrypted.DeriveStateKey("secret", "myapp")
+	if err != nil {
+		t.Fatalf("DeriveStateKey again: %v", err)
+	}
+	other, err := encrypted.DeriveStateKey("secret", "otherapp")
+	if err != nil {
+		t.Fatalf("DeriveStateKey other app: %v", err)
+	}
+	if len(first) != encrypted.KeySize || !bytes.Equal(first, again) {
+		t.Fatalf("key is not a stable %d-byte value", encrypted.KeySize)
+	}
+	if bytes.Equal(first, other) {
+		t.Fatal("apps sharing a passphrase share a key")
+	}
+	if _, err := encrypted.DeriveStateKey("", "myapp"); err == nil {
+		t.Fatal("empty passphrase accepted")
+	}
+}
+
+func TestEncryptedState_Errors(t *testing.T) {
+	useConfigDir(t, "myapp")
+	key := make([]byte, encrypted.KeySize)
+
+	if _, err := encrypted.LoadEncryptedState[token]("myapp", "none.bin", key); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("missing file error = %v, want os.ErrNotExist", err)
+	}
+	if err := encrypted.SaveEncryptedState("myapp", "state.bin", key[:16], token{}); err == nil {
+		t.Fatal("short key accepted")
+	}
+}
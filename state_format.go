@@ -0,0 +1,108 @@
+package gokart
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// StateFormat selects the encoding of a state file.
+type StateFormat string
+
+// Supported state formats. YAML and TOML use their own struct tags
+// (`yaml:"name"`, `toml:"name"`), not json tags.
+const (
+	StateFormatJSON StateFormat = "json"
+	StateFormatYAML StateFormat = "yaml"
+	StateFormatTOML StateFormat = "toml"
+)
+
+// SaveStateFormat saves state like SaveState using an explicit format.
+//
+// Example:
+//
+//	err := gokart.SaveStateFormat("myapp", "state", state, gokart.StateFormatYAML)
+func SaveStateFormat[T any](appName, filename string, data T, format StateFormat) error {
+	content, err := encodeState(format, data)
+	if err != nil {
+		return err
+	}
+	return writeState(appName, filename, content)
+}
+
+// LoadStateFormat loads state like LoadState using an explicit format.
+func LoadStateFormat[T any](appName, filename string, format StateFormat) (T, error) {
+	var zero T
+	content, err := readState(appName, filename)
+	if err != nil {
+		return zero, err
+	}
+	var result T
+	if err := decodeState(format, content, &result); err != nil {
+		return zero, err
+	}
+	return result, nil
+}
+
+func encodeState(format StateFormat, data any) ([]byte, error) {
+	var (
+		content []byte
+		err     error
+	)
+	switch format {
+	case StateFormatJSON:
+		content, err = json.MarshalIndent(data, "", "  ")
+	case StateFormatYAML:
+		var buf bytes.Buffer
+		encoder := yaml.NewEncoder(&buf)
+		encoder.SetIndent(2)
+		err = encoder.Encode(data)
+		if err == nil {
+			err = encoder.Close()
+		}
+		content = buf.Bytes()
+	case StateFormatTOML:
+		content, err = toml.Marshal(data)
+	default:
+		return nil, fmt.Errorf("unsupported state format %q", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("marshal state: %w", err)
+	}
+	return content, nil
+}
+
+func decodeState(format StateFormat, content []byte, dest any) error {
+	var err error
+	switch format {
+	case StateFormatJSON:
+		err = json.Unmarshal(content, dest)
+	case StateFormatYAML:
+		err = yaml.Unmarshal(content, dest)
+	case StateFormatTOML:
+		err = toml.Unmarshal(content, dest)
+	default:
+		return fmt.Errorf("unsupported state format %q", format)
+	}
+	if err != nil {
+		return fmt.Errorf("unmarshal state: %w", err)
+	}
+	return nil
+}
+
+// readState reads a state file, mapping a missing file to os.ErrNotExist.
+func readState(appName, filename string) ([]byte, error) {
+	content, err := os.ReadFile(StatePath(appName, filename))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, os.ErrNotExist
+		}
+		return nil, fmt.Errorf("read state file: %w", err)
+	}
+	return content, nil
+}
@@ -4,6 +4,8 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/dotcommander/gokart"
@@ -149,3 +151,84 @@ func TestState_DocZeroValue(t *testing.T) {
 		t.Errorf("Zero value should have empty Name, got %q", state.Name)
 	}
 }
+
+type formatWindow struct {
+	Width  int `json:"width" yaml:"width" toml:"width"`
+	Height int `json:"height" yaml:"height" toml:"height"`
+}
+
+type formatState struct {
+	Name    string       `json:"name" yaml:"name" toml:"name"`
+	Recent  []string     `json:"recent" yaml:"recent" toml:"recent"`
+	Window  formatWindow `json:"window" yaml:"window" toml:"window"`
+	Enabled bool         `json:"enabled" yaml:"enabled" toml:"enabled"`
+}
+
+func TestStateFormats_RoundTripNestedState(t *testing.T) {
+	appName := "gokart-test-formats-" + t.Name()
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(gokart.StatePath(appName, "x"))) })
+
+	original := formatState{
+		Name:    "editor",
+		Recent:  []string{"/tmp/a.txt", "/tmp/b.txt"},
+		Window:  formatWindow{Width: 1280, Height: 720},
+		Enabled: true,
+	}
+	tests := []struct {
+		filename string
+		format   gokart.StateFormat
+		readable string
+	}{
+		{"state.json", gokart.StateFormatJSON, `"name": "editor"`},
+		{"state.yaml", gokart.StateFormatYAML, "name: editor"},
+		{"state.yml", gokart.StateFormatYAML, "width: 1280"},
+		{"state.toml", gokart.StateFormatTOML, "[window]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.filename, func(t *testing.T) {
+			if err := gokart.SaveStateFormat(appName, tt.filename, original, tt.format); err != nil {
+				t.Fatalf("SaveStateFormat: %v", err)
+			}
+			content, err := os.ReadFile(gokart.StatePath(appName, tt.filename))
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if !strings.Contains(string(content), tt.readable) || !strings.Contains(string(content), "/tmp/a.txt") {
+				t.Fatalf("%s is not human-readable %s:\n%s", tt.filename, tt.format, content)
+			}
+
+			loaded, err := gokart.LoadStateFormat[formatState](appName, tt.filename, tt.format)
+			if err != nil {
+				t.Fatalf("LoadStateFormat: %v", err)
+			}
+			if !reflect.DeepEqual(loaded, original) {
+				t.Fatalf("loaded = %+v, want %+v", loaded, original)
+			}
+		})
+	}
+}
+
+func TestStateFormats_ExplicitFormatAndErrors(t *testing.T) {
+	appName := "gokart-test-formats-" + t.Name()
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(gokart.StatePath(appName, "x"))) })
+
+	if err := gokart.SaveStateFormat(appName, "state", formatState{Name: "plain"}, gokart.StateFormatYAML); err != nil {
+		t.Fatalf("SaveStateFormat: %v", err)
+	}
+	loaded, err := gokart.LoadStateFormat[formatState](appName, "state", gokart.StateFormatYAML)
+	if err != nil || loaded.Name != "plain" {
+		t.Fatalf("LoadStateFormat = %+v, %v", loaded, err)
+	}
+	if err := gokart.SaveStateFormat(appName, "state", formatState{}, gokart.StateFormat("ini")); err == nil {
+		t.Fatal("unsupported format accepted")
+	}
+	if err := gokart.SaveState(appName, "plain.yaml", formatState{Name: "json"}); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if content, err := os.ReadFile(gokart.StatePath(appName, "plain.yaml")); err != nil || !strings.Contains(string(content), `"name": "json"`) {
+		t.Fatalf("SaveState ignored JSON default for .yaml name: %s, %v", content, err)
+	}
+	if _, err := gokart.LoadStateFormat[formatState](appName, "missing.toml", gokart.StateFormatTOML); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("missing file error = %v, want os.ErrNotExist", err)
+	}
+}
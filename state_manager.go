@@ -0,0 +1,152 @@
+package gokart
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// StateManager serializes access to one state file for the goroutines of a
+// process. It does not lock against other processes.
+type StateManager[T any] struct {
+	appName  string
+	filename string
+
+	mu     sync.RWMutex
+	saved  []byte
+	closed chan struct{}
+	once   sync.Once
+}
+
+// NewStateManager returns a manager for the state file that SaveState and
+// LoadState address with the same appName and filename.
+//
+// Example:
+//
+//	state := gokart.NewStateManager[AppState]("myapp", "state.json")
+//	defer state.Close()
+//	err := state.Update(func(s AppState) AppState {
+//	    s.Launches++
+//	    return s
+//	})
+func NewStateManager[T any](appName, filename string) *StateManager[T] {
+	return &StateManager[T]{appName: appName, filename: filename, closed: make(chan struct{})}
+}
+
+// Load reads the state like LoadState, including os.ErrNotExist for a
+// missing file.
+func (m *StateManager[T]) Load() (T, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return LoadState[T](m.appName, m.filename)
+}
+
+// Save writes the state like SaveState.
+func (m *StateManager[T]) Save(data T) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.saveLocked(data)
+}
+
+// Update loads the state, or its zero value when the file is missing, applies
+// fn, and saves the result while holding the write lock.
+func (m *StateManager[T]) Update(fn func(T) T) error {
+	if fn == nil {
+		return fmt.Errorf("update state: nil function")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, err := LoadState[T](m.appName, m.filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return m.saveLocked(fn(current))
+}
+
+// Watch polls the state file every interval and calls fn with the decoded
+// state when another writer changes it. Saves made through this manager and
+// content that does not decode are skipped. Watch blocks until ctx ends or
+// the manager is closed, then returns nil.
+func (m *StateManager[T]) Watch(ctx context.Context, interval time.Duration, fn func(T)) error {
+	if interval <= 0 {
+		return fmt.Errorf("watch state: interval must be positive")
+	}
+	if fn == nil {
+		return fmt.Errorf("watch state: nil callback")
+	}
+	path := StatePath(m.appName, m.filename)
+	if path == "" {
+		return fmt.Errorf("watch state: user config directory is unavailable")
+	}
+	last, err := readStateFile(path)
+	if err != nil {
+		return fmt.Errorf("watch state: %w", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-m.closed:
+			return nil
+		case <-ticker.C:
+		}
+		content, err := readStateFile(path)
+		if err != nil {
+			return fmt.Errorf("watch state: %w", err)
+		}
+		if bytes.Equal(content, last) {
+			continue
+		}
+		last = content
+		m.mu.RLock()
+		own := bytes.Equal(content, m.saved)
+		m.mu.RUnlock()
+		if own || content == nil {
+			continue
+		}
+		var state T
+		if err := decodeState(StateFormatJSON, content, &state); err != nil {
+			continue
+		}
+		fn(state)
+	}
+}
+
+// Close stops running Watch calls. Later Save and Update calls fail.
+func (m *StateManager[T]) Close() error {
+	m.once.Do(func() { close(m.closed) })
+	return nil
+}
+
+func (m *StateManager[T]) saveLocked(data T) error {
+	select {
+	case <-m.closed:
+		return fmt.Errorf("save state: manager is closed")
+	default:
+	}
+	content, err := encodeState(StateFormatJSON, data)
+	if err != nil {
+		return err
+	}
+	if err := writeState(m.appName, m.filename, content); err != nil {
+		return err
+	}
+	m.saved = content
+	return nil
+}
+
+// readStateFile returns nil content for a missing file.
+func readStateFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return content, err
+}
@@ -0,0 +1,96 @@
+package gokart_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/gokart"
+)
+
+func TestStateManager_ConcurrentUpdates(t *testing.T) {
+	appName := "gokart-test-manager-" + t.Name()
+	path := gokart.StatePath(appName, "state.json")
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(path)) })
+
+	manager := gokart.NewStateManager[testState](appName, "state.json")
+	defer manager.Close()
+
+	var wg sync.WaitGroup
+	for range 100 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := manager.Update(func(s testState) testState {
+				s.Count++
+				return s
+			}); err != nil {
+				t.Errorf("Update: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	state, err := manager.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if state.Count != 100 {
+		t.Fatalf("Count = %d, want 100", state.Count)
+	}
+}
+
+func TestStateManager_WatchReportsExternalChanges(t *testing.T) {
+	appName := "gokart-test-manager-" + t.Name()
+	path := gokart.StatePath(appName, "state.json")
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(path)) })
+
+	manager := gokart.NewStateManager[testState](appName, "state.json")
+	if err := manager.Save(testState{Name: "initial"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	changes := make(chan testState, 4)
+	done := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		done <- manager.Watch(ctx, 10*time.Millisecond, func(s testState) { changes <- s })
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	if err := manager.Save(testState{Name: "own write"}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	time.Sleep(30 * time.Millisecond)
+	if err := gokart.SaveState(appName, "state.json", testState{Name: "external", Count: 7}); err != nil {
+		t.Fatalf("external SaveState: %v", err)
+	}
+
+	select {
+	case got := <-changes:
+		if got != (testState{Name: "external", Count: 7}) {
+			t.Fatalf("Watch reported %+v, want the external write", got)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Watch did not report the external write")
+	}
+
+	if err := manager.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Watch: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Watch did not stop after Close")
+	}
+	if err := manager.Save(testState{}); err == nil {
+		t.Fatal("Save after Close succeeded")
+	}
+}
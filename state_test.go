@@ -108,6 +108,42 @@ func TestSaveState_FailedPublicationPreservesExistingState(t *testing.T) {
 	}
 }
 
+// failingState fails to marshal, standing in for a writer that dies mid-save.
+type failingState struct{}
+
+func (failingState) MarshalJSON() ([]byte, error) {
+	return nil, errors.New("writer interrupted")
+}
+
+func TestSaveState_FailedWriteLeavesOriginalReadable(t *testing.T) {
+	appName := "gokart-test-interrupted-" + t.Name()
+	filename := "state.json"
+	path := gokart.StatePath(appName, filename)
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(path)) })
+
+	if err := gokart.SaveState(appName, filename, testState{Name: "original", Count: 1}); err != nil {
+		t.Fatalf("initial SaveState: %v", err)
+	}
+	if err := gokart.SaveState(appName, filename, failingState{}); err == nil {
+		t.Fatal("SaveState with failing marshaller succeeded")
+	}
+
+	loaded, err := gokart.LoadState[testState](appName, filename)
+	if err != nil {
+		t.Fatalf("LoadState after failed save: %v", err)
+	}
+	if loaded != (testState{Name: "original", Count: 1}) {
+		t.Fatalf("state after failed save = %+v", loaded)
+	}
+	entries, err := os.ReadDir(filepath.Dir(path))
+	if err != nil {
+		t.Fatalf("ReadDir: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("state directory holds %d entries, want only %s", len(entries), filename)
+	}
+}
+
 // TestState_DocSignatures verifies SaveState and LoadState signatures are documented
 func TestState_DocSignatures(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,86 @@
+package gokart
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// stateVersionKey is the JSON field that records a state file's schema version.
+const stateVersionKey = "_version"
+
+// StateMigration upgrades a raw JSON state document by one schema version.
+type StateMigration = func(json.RawMessage) (json.RawMessage, error)
+
+// SaveStateV saves JSON state like SaveState and records version in a
+// "_version" field. data must encode as a JSON object.
+//
+// Example:
+//
+//	err := gokart.SaveStateV("myapp", "state.json", 2, state)
+func SaveStateV[T any](appName, filename string, version int, data T) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err != nil || fields == nil {
+		return fmt.Errorf("marshal state: versioned state must be a JSON object")
+	}
+	fields[stateVersionKey] = json.RawMessage(fmt.Sprint(version))
+	content, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal state: %w", err)
+	}
+	return writeState(appName, filename, content)
+}
+
+// LoadStateV loads JSON state saved by SaveStateV, upgrading it to
+// currentVersion first. migrations[v] converts a version v document to
+// version v+1 and is applied in sequence from the stored version. Files
+// without "_version", such as those written by SaveState before versioning
+// was adopted, are version 0, so migrations[0] upgrades them to version 1.
+// The upgraded state is not written back; call SaveStateV to persist it.
+//
+// Example:
+//
+//	state, err := gokart.LoadStateV[StateV2]("myapp", "state.json", 2, map[int]gokart.StateMigration{
+//	    1: splitName,
+//	})
+func LoadStateV[T any](appName, filename string, currentVersion int, migrations map[int]StateMigration) (T, error) {
+	var zero T
+	content, err := readState(appName, filename)
+	if err != nil {
+		return zero, err
+	}
+
+	var header struct {
+		Version *int `json:"_version"`
+	}
+	if err := json.Unmarshal(content, &header); err != nil {
+		return zero, fmt.Errorf("unmarshal state: %w", err)
+	}
+	version := 0
+	if header.Version != nil {
+		version = *header.Version
+	}
+	if version > currentVersion {
+		return zero, fmt.Errorf("state version %d is newer than supported version %d", version, currentVersion)
+	}
+
+	raw := json.RawMessage(content)
+	for ; version < currentVersion; version++ {
+		migrate, ok := migrations[version]
+		if !ok || migrate == nil {
+			return zero, fmt.Errorf("no state migration from version %d", version)
+		}
+		if raw, err = migrate(raw); err != nil {
+			return zero, fmt.Errorf("migrate state from version %d: %w", version, err)
+		}
+	}
+
+	var result T
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return zero, fmt.Errorf("unmarshal state: %w", err)
+	}
+	return result, nil
+}
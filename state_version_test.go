@@ -0,0 +1,97 @@
+package gokart_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart"
+)
+
+type profileV1 struct {
+	Name string `json:"name"`
+}
+
+type profileV2 struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+func splitName(raw json.RawMessage) (json.RawMessage, error) {
+	var v1 profileV1
+	if err := json.Unmarshal(raw, &v1); err != nil {
+		return nil, err
+	}
+	first, last, _ := strings.Cut(v1.Name, " ")
+	return json.Marshal(profileV2{FirstName: first, LastName: last})
+}
+
+func TestLoadStateV_MigratesOldSchema(t *testing.T) {
+	appName := "gokart-test-versioned-" + t.Name()
+	path := gokart.StatePath(appName, "profile.json")
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(path)) })
+
+	if err := gokart.SaveStateV(appName, "profile.json", 1, profileV1{Name: "Ada Lovelace"}); err != nil {
+		t.Fatalf("SaveStateV: %v", err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !strings.Contains(string(content), `"_version": 1`) {
+		t.Fatalf("saved state lacks _version: %s", content)
+	}
+
+	migrations := map[int]gokart.StateMigration{1: splitName}
+	got, err := gokart.LoadStateV[profileV2](appName, "profile.json", 2, migrations)
+	if err != nil {
+		t.Fatalf("LoadStateV: %v", err)
+	}
+	if got != (profileV2{FirstName: "Ada", LastName: "Lovelace"}) {
+		t.Fatalf("migrated state = %+v", got)
+	}
+
+	if err := gokart.SaveStateV(appName, "profile.json", 2, got); err != nil {
+		t.Fatalf("SaveStateV v2: %v", err)
+	}
+	if again, err := gokart.LoadStateV[profileV2](appName, "profile.json", 2, nil); err != nil || again != got {
+		t.Fatalf("current-version load = %+v, %v", again, err)
+	}
+	if _, err := gokart.LoadStateV[profileV1](appName, "profile.json", 1, nil); err == nil {
+		t.Fatal("loading newer state with an older version succeeded")
+	}
+}
+
+func TestLoadStateV_UnversionedFileIsVersionZero(t *testing.T) {
+	appName := "gokart-test-versioned-" + t.Name()
+	path := gokart.StatePath(appName, "profile.json")
+	t.Cleanup(func() { _ = os.RemoveAll(filepath.Dir(path)) })
+
+	if err := gokart.SaveState(appName, "profile.json", profileV1{Name: "Grace Hopper"}); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if got, err := gokart.LoadStateV[profileV1](appName, "profile.json", 0, nil); err != nil || got.Name != "Grace Hopper" {
+		t.Fatalf("LoadStateV at version 0 = %+v, %v", got, err)
+	}
+	if _, err := gokart.LoadStateV[profileV2](appName, "profile.json", 2, map[int]gokart.StateMigration{1: splitName}); err == nil {
+		t.Fatal("missing migration from version 0 was not reported")
+	}
+
+	var fromZero int
+	adopt := func(raw json.RawMessage) (json.RawMessage, error) {
+		fromZero++
+		return raw, nil
+	}
+	got, err := gokart.LoadStateV[profileV2](appName, "profile.json", 2, map[int]gokart.StateMigration{0: adopt, 1: splitName})
+	if err != nil {
+		t.Fatalf("LoadStateV: %v", err)
+	}
+	if fromZero != 1 || got != (profileV2{FirstName: "Grace", LastName: "Hopper"}) {
+		t.Fatalf("migrated state = %+v after %d version 0 migrations", got, fromZero)
+	}
+	if err := gokart.SaveStateV(appName, "profile.json", 1, []string{"not", "an", "object"}); err == nil {
+		t.Fatal("SaveStateV accepted a non-object")
+	}
+}
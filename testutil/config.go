@@ -0,0 +1,42 @@
+package testutil
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+// TestConfigFile is the optional base file LoadTestConfig reads from the
+// package directory.
+const TestConfigFile = "config.test.yaml"
+
+// LoadTestConfig decodes configuration into T the way gokart.LoadConfig
+// does, but from a private viper instance that never reads environment
+// variables. It loads TestConfigFile when present, then applies overrides,
+// whose dotted keys (such as "db.host") take precedence. Because nothing is
+// shared, parallel tests can load conflicting values.
+//
+// Example:
+//
+//	cfg, err := testutil.LoadTestConfig[Config](t, map[string]string{
+//	    "db.host": "localhost",
+//	})
+func LoadTestConfig[T any](t testing.TB, overrides map[string]string) (T, error) {
+	t.Helper()
+	var cfg T
+	v := viper.New()
+	v.SetConfigFile(TestConfigFile)
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return cfg, fmt.Errorf("read config %q: %w", TestConfigFile, err)
+	}
+	for key, value := range overrides {
+		v.Set(key, value)
+	}
+	if err := v.Unmarshal(&cfg); err != nil {
+		return cfg, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,53 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/dotcommander/gokart/testutil"
+)
+
+type testConfig struct {
+	Name string `mapstructure:"name"`
+	DB   struct {
+		Host string `mapstructure:"host"`
+		Port int    `mapstructure:"port"`
+	} `mapstructure:"db"`
+}
+
+func TestLoadTestConfigReadsBaseFile(t *testing.T) {
+	t.Parallel()
+	t.Run("base", func(t *testing.T) {
+		t.Parallel()
+		cfg, err := testutil.LoadTestConfig[testConfig](t, nil)
+		if err != nil {
+			t.Fatalf("LoadTestConfig: %v", err)
+		}
+		if cfg.Name != "base" || cfg.DB.Host != "base-host" || cfg.DB.Port != 5432 {
+			t.Fatalf("cfg = %+v", cfg)
+		}
+	})
+	for _, host := range []string{"alpha", "beta"} {
+		t.Run(host, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := testutil.LoadTestConfig[testConfig](t, map[string]string{"db.host": host, "db.port": "6543"})
+			if err != nil {
+				t.Fatalf("LoadTestConfig: %v", err)
+			}
+			if cfg.DB.Host != host || cfg.DB.Port != 6543 || cfg.Name != "base" {
+				t.Fatalf("cfg = %+v, want host %s over the base file", cfg, host)
+			}
+		})
+	}
+}
+
+func TestLoadTestConfigIgnoresEnvironment(t *testing.T) {
+	t.Setenv("DB_HOST", "from-env")
+	t.Setenv("NAME", "from-env")
+	cfg, err := testutil.LoadTestConfig[testConfig](t, nil)
+	if err != nil {
+		t.Fatalf("LoadTestConfig: %v", err)
+	}
+	if cfg.DB.Host != "base-host" || cfg.Name != "base" {
+		t.Fatalf("environment leaked into cfg = %+v", cfg)
+	}
+}
@@ -0,0 +1,8 @@
+// Package testutil starts throwaway infrastructure for GoKart integration
+// tests. Container-backed helpers skip the calling test when no Docker
+// provider is reachable, so packages that use them still pass on machines
+// without Docker.
+//
+// testutil is its own module; only test code that imports it pays for the
+// testcontainers-go dependency.
+package testutil
@@ -0,0 +1,96 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dotcommander/gokart/logger"
+)
+
+// LogCapture collects JSON log lines for assertions. It is an io.Writer, so
+// it can also be used directly as logger.Config.Output.
+type LogCapture struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+// NewTestLogger returns a debug-level JSON logger that writes to a new
+// LogCapture.
+//
+// Example:
+//
+//	log, logs := testutil.NewTestLogger(t)
+//	svc := NewService(log)
+//	svc.Run()
+//	logs.AssertContains(t, "service started")
+func NewTestLogger(t testing.TB) (*slog.Logger, *LogCapture) {
+	t.Helper()
+	capture := &LogCapture{}
+	return logger.New(logger.Config{Level: "debug", Format: "json", Output: capture}), capture
+}
+
+// Write records p. It never fails.
+func (c *LogCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.buf.Write(p)
+}
+
+// Lines returns the complete lines written so far, without newlines.
+func (c *LogCapture) Lines() []string {
+	c.mu.Lock()
+	content := c.buf.String()
+	c.mu.Unlock()
+
+	end := strings.LastIndexByte(content, '\n')
+	if end < 0 {
+		return nil
+	}
+	return strings.Split(content[:end], "\n")
+}
+
+// HasMessage reports whether any record's message equals msg.
+func (c *LogCapture) HasMessage(msg string) bool {
+	return c.find(func(r logRecord) bool { return r.Msg == msg })
+}
+
+// HasLevel reports whether a record at level ("debug", "info", "warn", or
+// "error", in any case) has message msg.
+func (c *LogCapture) HasLevel(level, msg string) bool {
+	return c.find(func(r logRecord) bool { return r.Msg == msg && strings.EqualFold(r.Level, level) })
+}
+
+// Reset discards everything captured so far.
+func (c *LogCapture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buf.Reset()
+}
+
+// AssertContains fails t unless a record's message contains msg.
+func (c *LogCapture) AssertContains(t testing.TB, msg string) {
+	t.Helper()
+	if !c.find(func(r logRecord) bool { return strings.Contains(r.Msg, msg) }) {
+		t.Errorf("no log message contains %q; captured:\n%s", msg, strings.Join(c.Lines(), "\n"))
+	}
+}
+
+type logRecord struct {
+	Level string `json:"level"`
+	Msg   string `json:"msg"`
+}
+
+// find parses lines on demand; lines that are not JSON records are skipped.
+func (c *LogCapture) find(match func(logRecord) bool) bool {
+	for _, line := range c.Lines() {
+		var r logRecord
+		if json.Unmarshal([]byte(line), &r) == nil && match(r) {
+			return true
+		}
+	}
+	return false
+}
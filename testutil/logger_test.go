@@ -0,0 +1,54 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/dotcommander/gokart/logger"
+	"github.com/dotcommander/gokart/testutil"
+)
+
+func TestNewTestLogger(t *testing.T) {
+	t.Parallel()
+	log, logs := testutil.NewTestLogger(t)
+
+	log.Debug("cache warm", "keys", 3)
+	log.Info("server started", "addr", ":8080")
+	log.Warn("slow query")
+	log.Error("upstream failed")
+
+	if got := len(logs.Lines()); got != 4 {
+		t.Fatalf("Lines() has %d entries, want 4", got)
+	}
+	for _, tc := range []struct{ level, msg string }{
+		{"debug", "cache warm"}, {"INFO", "server started"}, {"warn", "slow query"}, {"error", "upstream failed"},
+	} {
+		if !logs.HasLevel(tc.level, tc.msg) {
+			t.Errorf("HasLevel(%q, %q) = false", tc.level, tc.msg)
+		}
+	}
+	if logs.HasLevel("error", "slow query") {
+		t.Error("HasLevel matched the wrong level")
+	}
+	if logs.HasMessage("server") {
+		t.Error("HasMessage matched a partial message")
+	}
+	logs.AssertContains(t, "server")
+
+	logs.Reset()
+	if logs.HasMessage("server started") || len(logs.Lines()) != 0 {
+		t.Errorf("Reset kept %q", logs.Lines())
+	}
+}
+
+func TestLogCaptureAsLoggerOutput(t *testing.T) {
+	t.Parallel()
+	logs := &testutil.LogCapture{}
+	log := logger.New(logger.Config{Output: logs})
+
+	log.Debug("filtered")
+	log.Info("kept")
+
+	if logs.HasMessage("filtered") || !logs.HasLevel("info", "kept") {
+		t.Fatalf("captured %q", logs.Lines())
+	}
+}
@@ -0,0 +1,141 @@
+package testutil
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/dotcommander/gokart/migrate"
+	"github.com/dotcommander/gokart/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/testcontainers/testcontainers-go"
+	tcpostgres "github.com/testcontainers/testcontainers-go/modules/postgres"
+)
+
+// PostgresImage is the container image started by NewTestPostgres.
+const PostgresImage = "postgres:17-alpine"
+
+// sharedPostgres is the one container every test in the binary shares.
+// testcontainers' reaper removes it when the test process exits.
+var sharedPostgres struct {
+	once sync.Once
+	url  string
+	err  error
+	next atomic.Int64
+}
+
+// NewTestPostgres returns a pool connected to a fresh, empty database and
+// applies migrations in order. Each FS is read like migrate.Config.FS, so
+// .sql files live in its "migrations" directory.
+//
+// All tests in a package share one PostgreSQL container, started on first
+// use; every call gets its own database, which t.Cleanup closes and drops.
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	pool := testutil.NewTestPostgres(t, migrations)
+func NewTestPostgres(t testing.TB, migrations ...fs.FS) *pgxpool.Pool {
+	t.Helper()
+	ctx := context.Background()
+	pool, err := postgres.OpenWithConfig(ctx, TestPostgresConfig(t))
+	if err != nil {
+		t.Fatalf("open test postgres: %v", err)
+	}
+	t.Cleanup(pool.Close)
+
+	if len(migrations) > 0 {
+		db := stdlib.OpenDBFromPool(pool)
+		defer db.Close()
+		for _, fsys := range migrations {
+			if err := migrate.Up(ctx, db, migrate.Config{FS: fsys, Dialect: "postgres"}); err != nil {
+				t.Fatalf("migrate test postgres: %v", err)
+			}
+		}
+	}
+	return pool
+}
+
+// TestPostgresConfig creates a fresh database in the shared container and
+// returns a small-pool postgres.Config for it, for tests that tune the pool
+// before calling postgres.OpenWithConfig. The database is dropped when the
+// test finishes, after pools registered later with t.Cleanup are closed.
+func TestPostgresConfig(t testing.TB) postgres.Config {
+	t.Helper()
+	ctx := context.Background()
+	baseURL := startPostgres(t)
+
+	name := fmt.Sprintf("gokart_test_%d", sharedPostgres.next.Add(1))
+	if err := execAdmin(ctx, baseURL, "CREATE DATABASE "+pgx.Identifier{name}.Sanitize()); err != nil {
+		t.Fatalf("create test database: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := execAdmin(ctx, baseURL, "DROP DATABASE IF EXISTS "+pgx.Identifier{name}.Sanitize()+" WITH (FORCE)"); err != nil {
+			t.Errorf("drop test database: %v", err)
+		}
+	})
+
+	dsn, err := url.Parse(baseURL)
+	if err != nil {
+		t.Fatalf("parse container url: %v", err)
+	}
+	dsn.Path = "/" + name
+	cfg := postgres.DefaultConfig(dsn.String())
+	cfg.MaxConns = 4
+	cfg.MinConns = 0
+	return cfg
+}
+
+func startPostgres(t testing.TB) string {
+	t.Helper()
+	requireDocker(t)
+	sharedPostgres.once.Do(func() {
+		ctx := context.Background()
+		container, err := tcpostgres.Run(ctx, PostgresImage,
+			tcpostgres.WithDatabase("postgres"),
+			tcpostgres.WithUsername("postgres"),
+			tcpostgres.WithPassword("postgres"),
+			tcpostgres.BasicWaitStrategies(),
+		)
+		if err != nil {
+			sharedPostgres.err = fmt.Errorf("start postgres container: %w", err)
+			return
+		}
+		sharedPostgres.url, sharedPostgres.err = container.ConnectionString(ctx, "sslmode=disable")
+	})
+	if sharedPostgres.err != nil {
+		t.Fatal(sharedPostgres.err)
+	}
+	return sharedPostgres.url
+}
+
+func execAdmin(ctx context.Context, baseURL, sql string) error {
+	conn, err := pgx.Connect(ctx, baseURL)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(ctx)
+	_, err = conn.Exec(ctx, sql)
+	return err
+}
+
+// requireDocker skips t when no container provider answers a health check.
+func requireDocker(t testing.TB) {
+	t.Helper()
+	provider, err := testcontainers.NewDockerProvider()
+	if err == nil {
+		defer provider.Close()
+		err = provider.Health(context.Background())
+	}
+	if err != nil {
+		t.Skipf("docker is unavailable: %v", err)
+	}
+}
@@ -0,0 +1,62 @@
+package testutil_test
+
+import (
+	"embed"
+	"io/fs"
+	"testing"
+
+	"github.com/dotcommander/gokart/postgres"
+	"github.com/dotcommander/gokart/testutil"
+)
+
+//go:embed testdata/migrations/*.sql
+var migrationFiles embed.FS
+
+func testMigrations(t testing.TB) fs.FS {
+	t.Helper()
+	fsys, err := fs.Sub(migrationFiles, "testdata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fsys
+}
+
+func TestNewTestPostgres(t *testing.T) {
+	pool := testutil.NewTestPostgres(t, testMigrations(t))
+
+	if _, err := pool.Exec(t.Context(), "INSERT INTO widgets (id, name) VALUES ($1, $2)", 1, "sprocket"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	var name string
+	if err := pool.QueryRow(t.Context(), "SELECT name FROM widgets WHERE id = $1", 1).Scan(&name); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if name != "sprocket" {
+		t.Fatalf("name = %q, want sprocket", name)
+	}
+}
+
+func TestNewTestPostgresIsolatesDatabases(t *testing.T) {
+	first := testutil.NewTestPostgres(t, testMigrations(t))
+	second := testutil.NewTestPostgres(t)
+
+	if _, err := first.Exec(t.Context(), "INSERT INTO widgets (id, name) VALUES (1, 'gear')"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	if _, err := second.Exec(t.Context(), "SELECT 1 FROM widgets"); err == nil {
+		t.Fatal("second database sees the first database's migrations")
+	}
+}
+
+func TestTestPostgresConfig(t *testing.T) {
+	cfg := testutil.TestPostgresConfig(t)
+	cfg.MaxConns = 1
+	pool, err := postgres.OpenWithConfig(t.Context(), cfg)
+	if err != nil {
+		t.Fatalf("OpenWithConfig: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	if got := pool.Config().MaxConns; got != 1 {
+		t.Fatalf("MaxConns = %d, want 1", got)
+	}
+}
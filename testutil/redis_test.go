@@ -0,0 +1,44 @@
+package testutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dotcommander/gokart/cache"
+	"github.com/dotcommander/gokart/testutil"
+)
+
+func TestNewTestCache(t *testing.T) {
+	t.Parallel()
+	c := testutil.NewTestCache(t)
+
+	if err := c.SetJSON(t.Context(), "greeting", map[string]string{"text": "hello"}, time.Minute); err != nil {
+		t.Fatalf("SetJSON: %v", err)
+	}
+	var got map[string]string
+	if err := c.GetJSON(t.Context(), "greeting", &got); err != nil {
+		t.Fatalf("GetJSON: %v", err)
+	}
+	if got["text"] != "hello" {
+		t.Fatalf("GetJSON = %v", got)
+	}
+}
+
+func TestNewTestCacheAddrIsPerTest(t *testing.T) {
+	t.Parallel()
+	first, second := testutil.NewTestCacheAddr(t), testutil.NewTestCacheAddr(t)
+	if first == second {
+		t.Fatalf("two calls share the container at %s", first)
+	}
+	c, err := cache.Open(t.Context(), first)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	defer c.Close()
+	if err := c.Client().Set(t.Context(), c.Key("k"), "v", 0).Err(); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, err := c.Client().Get(t.Context(), c.Key("k")).Result(); err != nil || got != "v" {
+		t.Fatalf("Get = %q, %v", got, err)
+	}
+}
@@ -0,0 +1,104 @@
+package testutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/dotcommander/gokart/web"
+	"github.com/go-chi/chi/v5"
+)
+
+// TestServer is a running httptest.Server backed by a web.NewRouter router.
+type TestServer struct {
+	server *httptest.Server
+	router chi.Router
+	client *http.Client
+}
+
+// NewTestServer starts a server for a router built from cfg. Routes may be
+// registered on Router before or after it starts. t.Cleanup stops the
+// server.
+//
+// Example:
+//
+//	srv := testutil.NewTestServer(t, web.RouterConfig{})
+//	srv.Router().Get("/health", health)
+//	resp := srv.MustGet("/health")
+//	defer resp.Body.Close()
+func NewTestServer(t testing.TB, cfg web.RouterConfig) *TestServer {
+	t.Helper()
+	router := web.NewRouter(cfg)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	base, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("parse test server url: %v", err)
+	}
+	client := server.Client()
+	client.Transport = baseURLTransport{base: base, next: client.Transport}
+	return &TestServer{server: server, router: router, client: client}
+}
+
+// URL returns the server's base URL, such as "http://127.0.0.1:41234".
+func (s *TestServer) URL() string { return s.server.URL }
+
+// Router returns the router serving requests.
+func (s *TestServer) Router() chi.Router { return s.router }
+
+// Client returns a client that resolves relative request URLs, such as
+// "/health", against the server's URL.
+func (s *TestServer) Client() *http.Client { return s.client }
+
+// MustGet sends a GET request for path. It panics on transport errors, not
+// on error statuses. The caller closes the response body.
+func (s *TestServer) MustGet(path string) *http.Response {
+	resp, err := s.client.Get(path)
+	if err != nil {
+		panic(fmt.Sprintf("testutil: GET %s: %v", path, err))
+	}
+	return resp
+}
+
+// MustPost sends body as JSON in a POST request for path; a nil body sends
+// no content. It panics on encoding or transport errors, not on error
+// statuses. The caller closes the response body.
+func (s *TestServer) MustPost(path string, body any) *http.Response {
+	var (
+		reader      io.Reader
+		contentType string
+	)
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			panic(fmt.Sprintf("testutil: POST %s: encode body: %v", path, err))
+		}
+		reader, contentType = bytes.NewReader(data), "application/json"
+	}
+	resp, err := s.client.Post(path, contentType, reader)
+	if err != nil {
+		panic(fmt.Sprintf("testutil: POST %s: %v", path, err))
+	}
+	return resp
+}
+
+// baseURLTransport sends requests without a host to base.
+type baseURLTransport struct {
+	base *url.URL
+	next http.RoundTripper
+}
+
+func (t baseURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host == "" {
+		req = req.Clone(req.Context())
+		req.URL = t.base.ResolveReference(req.URL)
+		req.Host = req.URL.Host
+	}
+	return t.next.RoundTrip(req)
+}
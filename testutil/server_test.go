@@ -0,0 +1,75 @@
+package testutil_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dotcommander/gokart/testutil"
+	"github.com/dotcommander/gokart/web"
+)
+
+func TestNewTestServer(t *testing.T) {
+	t.Parallel()
+	srv := testutil.NewTestServer(t, web.RouterConfig{})
+	srv.Router().Get("/greet", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, "hello "+r.URL.Query().Get("name"))
+	})
+	srv.Router().Post("/echo", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, r.Header.Get("Content-Type")+" "+body["name"])
+	})
+
+	resp := srv.MustGet("/greet?name=ada")
+	if got := readBody(t, resp); resp.StatusCode != http.StatusOK || got != "hello ada" {
+		t.Fatalf("GET /greet = %d %q", resp.StatusCode, got)
+	}
+
+	resp = srv.MustPost("/echo", map[string]string{"name": "grace"})
+	if got := readBody(t, resp); resp.StatusCode != http.StatusCreated || got != "application/json grace" {
+		t.Fatalf("POST /echo = %d %q", resp.StatusCode, got)
+	}
+
+	resp = srv.MustGet("/missing")
+	readBody(t, resp)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("GET /missing = %d, want 404", resp.StatusCode)
+	}
+
+	resp, err := srv.Client().Get(srv.URL() + "/greet?name=absolute")
+	if err != nil {
+		t.Fatalf("absolute GET: %v", err)
+	}
+	if got := readBody(t, resp); got != "hello absolute" {
+		t.Fatalf("absolute GET body = %q", got)
+	}
+}
+
+func TestTestServerMustGetPanicsOnTransportError(t *testing.T) {
+	t.Parallel()
+	srv := testutil.NewTestServer(t, web.RouterConfig{})
+	defer func() {
+		if r := recover(); r == nil || !strings.Contains(r.(string), "GET") {
+			t.Fatalf("recover() = %v, want GET transport panic", r)
+		}
+	}()
+	srv.MustGet("http://127.0.0.1:0/unreachable")
+}
+
+func readBody(t *testing.T, resp *http.Response) string {
+	t.Helper()
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	return string(data)
+}
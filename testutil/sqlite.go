@@ -0,0 +1,67 @@
+package testutil
+
+import (
+	"context"
+	"database/sql"
+	"io/fs"
+	"path/filepath"
+	"testing"
+
+	"github.com/dotcommander/gokart/migrate"
+	"github.com/dotcommander/gokart/sqlite"
+)
+
+// testSQLiteFileConns bounds the pool NewTestSQLiteFile opens so tests can
+// exercise concurrent WAL readers.
+const testSQLiteFileConns = 4
+
+// NewTestSQLite opens an in-memory database, applies migrations, and closes
+// it in t.Cleanup. The pool holds exactly one connection because every new
+// :memory: connection is a separate, empty database. migrations is read like
+// migrate.Config.FS, with .sql files in its "migrations" directory; nil
+// skips migration.
+//
+// Example:
+//
+//	//go:embed migrations/*.sql
+//	var migrations embed.FS
+//
+//	db := testutil.NewTestSQLite(t, migrations)
+func NewTestSQLite(t testing.TB, migrations fs.FS) *sql.DB {
+	t.Helper()
+	cfg := sqlite.DefaultConfig(":memory:")
+	cfg.MaxOpenConns = 1
+	cfg.MaxIdleConns = 1
+	return openTestSQLite(t, cfg, migrations)
+}
+
+// NewTestSQLiteFile is like NewTestSQLite but uses a WAL-mode database file
+// in t.TempDir() and allows several connections, for tests of journal or
+// concurrency behavior.
+func NewTestSQLiteFile(t testing.TB, migrations fs.FS) *sql.DB {
+	t.Helper()
+	cfg := sqlite.DefaultConfig(filepath.Join(t.TempDir(), "test.db"))
+	cfg.MaxOpenConns = testSQLiteFileConns
+	cfg.MaxIdleConns = testSQLiteFileConns
+	return openTestSQLite(t, cfg, migrations)
+}
+
+func openTestSQLite(t testing.TB, cfg sqlite.Config, migrations fs.FS) *sql.DB {
+	t.Helper()
+	ctx := context.Background()
+	db, err := sqlite.OpenWithConfig(ctx, cfg)
+	if err != nil {
+		t.Fatalf("open test sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := db.Close(); err != nil {
+			t.Errorf("close test sqlite: %v", err)
+		}
+	})
+	if migrations != nil {
+		if err := migrate.Up(ctx, db, migrate.Config{FS: migrations, Dialect: "sqlite3"}); err != nil {
+			t.Fatalf("migrate test sqlite: %v", err)
+		}
+	}
+	return db
+}
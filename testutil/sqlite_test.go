@@ -0,0 +1,53 @@
+package testutil_test
+
+import (
+	"testing"
+
+	"github.com/dotcommander/gokart/testutil"
+)
+
+func TestNewTestSQLite(t *testing.T) {
+	t.Parallel()
+	db := testutil.NewTestSQLite(t, testMigrations(t))
+
+	if _, err := db.ExecContext(t.Context(), "INSERT INTO widgets (id, name) VALUES (?, ?)", 1, "sprocket"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+	var name string
+	if err := db.QueryRowContext(t.Context(), "SELECT name FROM widgets WHERE id = ?", 1).Scan(&name); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	if name != "sprocket" {
+		t.Fatalf("name = %q, want sprocket", name)
+	}
+	if got := db.Stats().MaxOpenConnections; got != 1 {
+		t.Fatalf("MaxOpenConnections = %d, want 1", got)
+	}
+}
+
+func TestNewTestSQLiteFile(t *testing.T) {
+	t.Parallel()
+	db := testutil.NewTestSQLiteFile(t, testMigrations(t))
+
+	var mode string
+	if err := db.QueryRowContext(t.Context(), "PRAGMA journal_mode").Scan(&mode); err != nil {
+		t.Fatalf("journal_mode: %v", err)
+	}
+	if mode != "wal" {
+		t.Fatalf("journal_mode = %q, want wal", mode)
+	}
+	if got := db.Stats().MaxOpenConnections; got < 2 {
+		t.Fatalf("MaxOpenConnections = %d, want several", got)
+	}
+	if _, err := db.ExecContext(t.Context(), "INSERT INTO widgets (id, name) VALUES (1, 'gear')"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+}
+
+func TestNewTestSQLiteWithoutMigrations(t *testing.T) {
+	t.Parallel()
+	db := testutil.NewTestSQLite(t, nil)
+	if _, err := db.ExecContext(t.Context(), "SELECT 1 FROM widgets"); err == nil {
+		t.Fatal("unmigrated database has a widgets table")
+	}
+}
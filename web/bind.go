@@ -2,6 +2,7 @@ package web
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-playground/validator/v10"
@@ -71,3 +72,41 @@ func BindAndValidate(r *http.Request, v *validator.Validate, dst any) (map[strin
 
 	return nil, nil
 }
+
+// BindValidated decodes and validates a JSON request body into a T and
+// writes the error response itself, so handlers only branch on success:
+//
+//   - 413 with web.Error when the body exceeds DefaultMaxRequestBodyBytes
+//   - 400 with web.Error for malformed JSON
+//   - 422 with {"errors": {field: message}} when validation fails
+//   - 500 when v cannot validate T, such as a non-struct type
+//
+// It returns false after writing an error response.
+//
+// Example:
+//
+//	input, ok := web.BindValidated[CreateUserRequest](w, r, v)
+//	if !ok {
+//	    return
+//	}
+func BindValidated[T any](w http.ResponseWriter, r *http.Request, v *validator.Validate) (T, bool) {
+	var dst T
+	fields, err := BindAndValidate(r, v, &dst)
+	var (
+		tooLarge *http.MaxBytesError
+		invalid  *validator.InvalidValidationError
+	)
+	switch {
+	case errors.As(err, &tooLarge):
+		Error(w, http.StatusRequestEntityTooLarge, "request too large")
+	case errors.As(err, &invalid):
+		Error(w, http.StatusInternalServerError, "internal server error")
+	case err != nil:
+		Error(w, http.StatusBadRequest, "invalid JSON")
+	case fields != nil:
+		JSONStatus(w, http.StatusUnprocessableEntity, map[string]any{"errors": fields})
+	default:
+		return dst, true
+	}
+	return dst, false
+}
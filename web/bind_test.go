@@ -206,3 +206,49 @@ func TestBindJSONWithLimit_ZeroDisables(t *testing.T) {
 		t.Errorf("expected Name Alice, got %q", u.Name)
 	}
 }
+
+func TestBindValidated(t *testing.T) {
+	t.Parallel()
+
+	v := web.NewStandardValidator()
+	tests := []struct {
+		name       string
+		body       string
+		wantOK     bool
+		wantStatus int
+		wantBody   string
+	}{
+		{"valid", `{"name":"Alice","email":"alice@example.com","age":30}`, true, http.StatusOK, ""},
+		{"malformed", `{bad`, false, http.StatusBadRequest, `"invalid JSON"`},
+		{"invalid fields", `{"name":"","email":"nope"}`, false, http.StatusUnprocessableEntity, `"errors"`},
+		{"too large", `{"name":"` + strings.Repeat("a", int(web.DefaultMaxRequestBodyBytes)) + `"}`, false, http.StatusRequestEntityTooLarge, `"request too large"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rec := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+
+			u, ok := web.BindValidated[bindTestUser](rec, r, v)
+			if ok != tt.wantOK || rec.Code != tt.wantStatus {
+				t.Fatalf("ok=%v status=%d, want ok=%v status=%d (body %s)", ok, rec.Code, tt.wantOK, tt.wantStatus, rec.Body)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body %q missing %q", rec.Body, tt.wantBody)
+			}
+			if ok && u.Name != "Alice" {
+				t.Errorf("decoded Name = %q, want Alice", u.Name)
+			}
+		})
+	}
+}
+
+func TestBindValidated_RejectsUnvalidatableType(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`["a"]`))
+	if _, ok := web.BindValidated[[]string](rec, r, web.NewStandardValidator()); ok || rec.Code != http.StatusInternalServerError {
+		t.Fatalf("ok=%v status=%d, want false and 500", ok, rec.Code)
+	}
+}
@@ -0,0 +1,97 @@
+package web
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// SetETag sets the ETag header, quoting etag when it is not already a quoted
+// or weak (W/"...") entity tag.
+func SetETag(w http.ResponseWriter, etag string) {
+	if etag == "" {
+		return
+	}
+	w.Header().Set("ETag", quoteETag(etag))
+}
+
+// SetLastModified sets the Last-Modified header in HTTP date format.
+// A zero time is ignored.
+func SetLastModified(w http.ResponseWriter, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	w.Header().Set("Last-Modified", t.UTC().Format(http.TimeFormat))
+}
+
+// CacheControl sets the Cache-Control header to the given directives,
+// e.g. CacheControl(w, "private", "max-age=60").
+func CacheControl(w http.ResponseWriter, directives ...string) {
+	w.Header().Set("Cache-Control", strings.Join(directives, ", "))
+}
+
+// CheckNotModified reports whether the client's cached copy of a GET or HEAD
+// response is still fresh. If-None-Match is evaluated against etag with weak
+// comparison; If-Modified-Since is only consulted when If-None-Match is
+// absent, as RFC 9110 requires. When it returns true the caller should write
+// 304 Not Modified.
+func CheckNotModified(r *http.Request, etag string, modTime time.Time) bool {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		return false
+	}
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		return etag != "" && etagListMatches(inm, quoteETag(etag))
+	}
+	ims := r.Header.Get("If-Modified-Since")
+	if ims == "" || modTime.IsZero() {
+		return false
+	}
+	since, err := http.ParseTime(ims)
+	if err != nil {
+		return false
+	}
+	return !modTime.Truncate(time.Second).After(since)
+}
+
+// ConditionalGet sets the ETag and Last-Modified validators and, when the
+// client's cached copy is fresh, writes 304 Not Modified and returns true.
+//
+// Example:
+//
+//	if web.ConditionalGet(w, r, article.Version, article.UpdatedAt) {
+//	    return
+//	}
+//	web.JSON(w, article)
+func ConditionalGet(w http.ResponseWriter, r *http.Request, etag string, modTime time.Time) bool {
+	SetETag(w, etag)
+	SetLastModified(w, modTime)
+	if !CheckNotModified(r, etag, modTime) {
+		return false
+	}
+	// Drop representation headers a 304 must not describe.
+	h := w.Header()
+	delete(h, "Content-Type")
+	delete(h, "Content-Length")
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+func quoteETag(etag string) string {
+	if strings.HasPrefix(etag, `W/"`) || (len(etag) >= 2 && strings.HasPrefix(etag, `"`) && strings.HasSuffix(etag, `"`)) {
+		return etag
+	}
+	return `"` + etag + `"`
+}
+
+// etagListMatches reports whether an If-None-Match list matches etag using
+// weak comparison.
+func etagListMatches(list, etag string) bool {
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(list, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,87 @@
+package web_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dotcommander/gokart/web"
+)
+
+func TestConditionalGet(t *testing.T) {
+	t.Parallel()
+
+	modTime := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		want    int
+	}{
+		{name: "no validators", want: http.StatusOK},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": `"v2"`}, want: http.StatusNotModified},
+		{name: "weak etag in list", headers: map[string]string{"If-None-Match": `"v1", W/"v2"`}, want: http.StatusNotModified},
+		{name: "wildcard", headers: map[string]string{"If-None-Match": "*"}, want: http.StatusNotModified},
+		{name: "mismatched etag", headers: map[string]string{"If-None-Match": `"v1"`}, want: http.StatusOK},
+		{
+			name: "etag mismatch wins over fresh date",
+			headers: map[string]string{
+				"If-None-Match":     `"v1"`,
+				"If-Modified-Since": modTime.Format(http.TimeFormat),
+			},
+			want: http.StatusOK,
+		},
+		{name: "not modified since", headers: map[string]string{"If-Modified-Since": modTime.Format(http.TimeFormat)}, want: http.StatusNotModified},
+		{name: "modified since", headers: map[string]string{"If-Modified-Since": modTime.Add(-time.Hour).Format(http.TimeFormat)}, want: http.StatusOK},
+		{name: "invalid date", headers: map[string]string{"If-Modified-Since": "yesterday"}, want: http.StatusOK},
+		{name: "unsafe method", method: http.MethodPost, headers: map[string]string{"If-None-Match": `"v2"`}, want: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			method := tt.method
+			if method == "" {
+				method = http.MethodGet
+			}
+			req := httptest.NewRequest(method, "/articles/1", nil)
+			for k, v := range tt.headers {
+				req.Header.Set(k, v)
+			}
+			rec := httptest.NewRecorder()
+			if !web.ConditionalGet(rec, req, "v2", modTime.Add(500*time.Millisecond)) {
+				web.JSON(rec, map[string]string{"id": "1"})
+			}
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if got := rec.Header().Get("ETag"); got != `"v2"` {
+				t.Errorf("ETag = %q, want %q", got, `"v2"`)
+			}
+			if got := rec.Header().Get("Last-Modified"); got != modTime.Format(http.TimeFormat) {
+				t.Errorf("Last-Modified = %q", got)
+			}
+			if tt.want == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Errorf("304 body = %q, want empty", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCacheControl(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	web.CacheControl(rec, "private", "max-age=60")
+	web.SetETag(rec, `W/"abc"`)
+
+	if got := rec.Header().Get("Cache-Control"); got != "private, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+	if got := rec.Header().Get("ETag"); got != `W/"abc"` {
+		t.Errorf("ETag = %q, want weak tag unchanged", got)
+	}
+}
@@ -38,6 +38,11 @@ func DefaultServerConfig() ServerConfig {
 type RouterConfig struct {
 	Middleware []func(http.Handler) http.Handler
 	Timeout    time.Duration // request timeout (default: none)
+	// SlowRequestThreshold logs a "slow request" warning to SlowRequestLogger
+	// for every request that takes at least this long; zero disables it.
+	SlowRequestThreshold time.Duration
+	// SlowRequestLogger receives slow-request warnings; nil uses slog.Default().
+	SlowRequestLogger *slog.Logger
 }
 
 // StandardMiddleware provides production-ready middleware stack:
@@ -74,6 +79,11 @@ func NewRouter(cfg RouterConfig) chi.Router {
 		r.Use(mw)
 	}
 
+	// Time requests after caller middleware so request_id is available
+	if cfg.SlowRequestThreshold > 0 {
+		r.Use(NewSlowRequestMiddleware(cfg.SlowRequestThreshold, cfg.SlowRequestLogger))
+	}
+
 	// Apply timeout if configured
 	if cfg.Timeout > 0 {
 		r.Use(middleware.Timeout(cfg.Timeout))
@@ -82,6 +92,43 @@ func NewRouter(cfg RouterConfig) chi.Router {
 	return r
 }
 
+// NewSlowRequestMiddleware logs a "slow request" warning for every request
+// that takes at least threshold, with method, path, status, duration, and the
+// request_id set by middleware.RequestID. A nil logger uses slog.Default().
+// RouterConfig.SlowRequestThreshold installs it on a router.
+//
+// Example:
+//
+//	router.Use(web.NewSlowRequestMiddleware(time.Second, logger))
+func NewSlowRequestMiddleware(threshold time.Duration, logger *slog.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+			next.ServeHTTP(ww, r)
+			elapsed := time.Since(start)
+			if elapsed < threshold {
+				return
+			}
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+			log := logger
+			if log == nil {
+				log = slog.Default()
+			}
+			log.WarnContext(r.Context(), "slow request",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"status", status,
+				"duration", elapsed,
+				"request_id", middleware.GetReqID(r.Context()),
+			)
+		})
+	}
+}
+
 // Serve starts an HTTP server that shuts down when ctx is cancelled.
 func Serve(ctx context.Context, addr string, handler http.Handler, cfg ServerConfig) error {
 	srv := &http.Server{
@@ -2,11 +2,20 @@ package web
 
 import (
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"path"
+	"time"
 )
 
-const jsonContentType = "application/json"
+const (
+	jsonContentType    = "application/json"
+	problemContentType = "application/problem+json"
+	xmlContentType     = "application/xml; charset=utf-8"
+)
 
 func writeJSON(w http.ResponseWriter, status int, data any) error {
 	w.Header().Set("Content-Type", jsonContentType)
@@ -38,7 +47,165 @@ func Error(w http.ResponseWriter, status int, message string) {
 	JSONStatus(w, status, map[string]string{"error": message})
 }
 
+func writeXML(w http.ResponseWriter, status int, data any) error {
+	w.Header().Set("Content-Type", xmlContentType)
+	w.WriteHeader(status)
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("xml write: %w", err)
+	}
+	if err := xml.NewEncoder(w).Encode(data); err != nil {
+		return fmt.Errorf("xml encode: %w", err)
+	}
+	return nil
+}
+
+// XML writes an XML response with status 200.
+func XML(w http.ResponseWriter, data any) {
+	XMLStatus(w, http.StatusOK, data)
+}
+
+// XMLStatus writes an XML response with the given status code.
+func XMLStatus(w http.ResponseWriter, status int, data any) {
+	_ = writeXML(w, status, data)
+}
+
+// XMLStatusE writes an XML response with the given status code.
+// Returns an error if XML encoding fails.
+func XMLStatusE(w http.ResponseWriter, status int, data any) error {
+	return writeXML(w, status, data)
+}
+
 // NoContent writes a 204 No Content response.
 func NoContent(w http.ResponseWriter) {
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// ProblemDetail is an RFC 7807 problem details object. Extensions are
+// written as additional top-level members and cannot replace the standard
+// ones.
+type ProblemDetail struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+// MarshalJSON flattens Extensions into the problem object and omits empty
+// standard members.
+func (p ProblemDetail) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]any, len(p.Extensions)+5)
+	for name, value := range p.Extensions {
+		fields[name] = value
+	}
+	standard := []struct {
+		name  string
+		value string
+	}{
+		{"type", p.Type},
+		{"title", p.Title},
+		{"detail", p.Detail},
+		{"instance", p.Instance},
+	}
+	for _, member := range standard {
+		if member.value != "" {
+			fields[member.name] = member.value
+		} else {
+			delete(fields, member.name)
+		}
+	}
+	if p.Status != 0 {
+		fields["status"] = p.Status
+	} else {
+		delete(fields, "status")
+	}
+	return json.Marshal(fields)
+}
+
+// Problem writes p as an application/problem+json response with p.Status,
+// defaulting to 500 when Status is unset.
+func Problem(w http.ResponseWriter, p ProblemDetail) {
+	status := p.Status
+	if status == 0 {
+		status = http.StatusInternalServerError
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(p)
+}
+
+// NotFound returns a 404 problem for the resource at instance.
+func NotFound(instance, detail string) ProblemDetail {
+	return ProblemDetail{
+		Type:     "about:blank",
+		Title:    http.StatusText(http.StatusNotFound),
+		Status:   http.StatusNotFound,
+		Detail:   detail,
+		Instance: instance,
+	}
+}
+
+// UnprocessableEntity returns a 422 problem, typically carrying validation
+// failures in extensions.
+func UnprocessableEntity(detail string, extensions map[string]any) ProblemDetail {
+	return ProblemDetail{
+		Type:       "about:blank",
+		Title:      http.StatusText(http.StatusUnprocessableEntity),
+		Status:     http.StatusUnprocessableEntity,
+		Detail:     detail,
+		Extensions: extensions,
+	}
+}
+
+// Download serves content as a file attachment named filename through
+// http.ServeContent, which sets Content-Type from the file extension or the
+// content and handles Range and conditional requests. Download only adds the
+// Content-Disposition header. content must be a non-nil reader; a nil
+// interface or a nil pointer such as a (*bytes.Reader)(nil) panics. Download
+// returns an error without writing a response when content cannot seek, so
+// the caller can still choose the status.
+//
+// Example:
+//
+//	f, err := os.Open(report.Path)
+//	if err != nil {
+//	    web.Error(w, http.StatusNotFound, "report not found")
+//	    return
+//	}
+//	defer f.Close()
+//	if err := web.Download(w, r, "report.csv", f); err != nil {
+//	    web.Error(w, http.StatusInternalServerError, "report unreadable")
+//	}
+func Download(w http.ResponseWriter, r *http.Request, filename string, content io.ReadSeeker) error {
+	return serveFile(w, r, "attachment", filename, content)
+}
+
+// Inline serves content like Download but asks the browser to display it
+// rather than save it.
+func Inline(w http.ResponseWriter, r *http.Request, filename string, content io.ReadSeeker) error {
+	return serveFile(w, r, "inline", filename, content)
+}
+
+func serveFile(w http.ResponseWriter, r *http.Request, disposition, filename string, content io.ReadSeeker) error {
+	// http.ServeContent answers a failed seek with a plain-text 500, so
+	// probe the reader first and leave that response to the caller.
+	if _, err := content.Seek(0, io.SeekEnd); err != nil {
+		return fmt.Errorf("serve %s: seek: %w", disposition, err)
+	}
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("serve %s: seek: %w", disposition, err)
+	}
+	name := path.Base(filename)
+	params := map[string]string{"filename": name}
+	if filename == "" {
+		name, params = "", nil
+	}
+	header := mime.FormatMediaType(disposition, params)
+	if header == "" {
+		header = disposition
+	}
+	w.Header().Set("Content-Disposition", header)
+	http.ServeContent(w, r, name, time.Time{}, content)
+	return nil
+}
@@ -1,11 +1,22 @@
 package web_test
 
 import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/go-chi/chi/v5/middleware"
+
 	"github.com/dotcommander/gokart/web"
 )
 
@@ -34,6 +45,50 @@ func TestNewRouter(t *testing.T) {
 	}
 }
 
+func TestNewRouterLogsSlowRequests(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	router := web.NewRouter(web.RouterConfig{
+		Middleware:           []func(http.Handler) http.Handler{middleware.RequestID},
+		SlowRequestThreshold: 10 * time.Millisecond,
+		SlowRequestLogger:    slog.New(slog.NewJSONHandler(&buf, nil)),
+	})
+	router.Get("/slow", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(50 * time.Millisecond)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	router.Get("/fast", func(w http.ResponseWriter, r *http.Request) {})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/fast", nil))
+	if buf.Len() != 0 {
+		t.Fatalf("fast request logged: %s", buf.String())
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/slow", nil))
+	var entry struct {
+		Level     string  `json:"level"`
+		Msg       string  `json:"msg"`
+		Method    string  `json:"method"`
+		Path      string  `json:"path"`
+		Status    int     `json:"status"`
+		Duration  float64 `json:"duration"`
+		RequestID string  `json:"request_id"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("decode log %q: %v", buf.String(), err)
+	}
+	if entry.Level != "WARN" || entry.Msg != "slow request" || entry.Method != http.MethodGet || entry.Path != "/slow" || entry.Status != http.StatusAccepted {
+		t.Fatalf("log entry = %+v", entry)
+	}
+	if time.Duration(entry.Duration) < 50*time.Millisecond {
+		t.Fatalf("duration = %v, want at least 50ms", time.Duration(entry.Duration))
+	}
+	if entry.RequestID == "" {
+		t.Fatal("request_id missing from slow request log")
+	}
+}
+
 func ExampleNewRouter() {
 	router := web.NewRouter(web.RouterConfig{
 		Middleware: web.StandardMiddleware,
@@ -44,3 +99,210 @@ func ExampleNewRouter() {
 		w.WriteHeader(http.StatusOK)
 	})
 }
+
+func TestProblem(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		p      web.ProblemDetail
+		status int
+		want   map[string]any
+	}{
+		{
+			name:   "not found",
+			p:      web.NotFound("/users/42", "user 42 does not exist"),
+			status: http.StatusNotFound,
+			want: map[string]any{
+				"type":     "about:blank",
+				"title":    "Not Found",
+				"status":   float64(404),
+				"detail":   "user 42 does not exist",
+				"instance": "/users/42",
+			},
+		},
+		{
+			name: "unprocessable entity with extensions",
+			p: web.UnprocessableEntity("validation failed", map[string]any{
+				"errors": map[string]any{"email": "required"},
+				"status": "ignored",
+			}),
+			status: http.StatusUnprocessableEntity,
+			want: map[string]any{
+				"type":   "about:blank",
+				"title":  "Unprocessable Entity",
+				"status": float64(422),
+				"detail": "validation failed",
+				"errors": map[string]any{"email": "required"},
+			},
+		},
+		{
+			name:   "missing status defaults to 500",
+			p:      web.ProblemDetail{Title: "Internal Server Error"},
+			status: http.StatusInternalServerError,
+			want:   map[string]any{"title": "Internal Server Error"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			web.Problem(rec, tt.p)
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", ct)
+			}
+			var got map[string]any
+			if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("body = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDownload(t *testing.T) {
+	t.Parallel()
+
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 32)...)
+	tests := []struct {
+		name        string
+		serve       func(http.ResponseWriter, *http.Request, string, io.ReadSeeker) error
+		filename    string
+		content     []byte
+		disposition string
+		contentType string
+	}{
+		{
+			name:        "attachment by extension",
+			serve:       web.Download,
+			filename:    "reports/q1.json",
+			content:     []byte(`{"total":10}`),
+			disposition: `attachment; filename=q1.json`,
+			contentType: "application/json",
+		},
+		{
+			name:        "inline sniffed",
+			serve:       web.Inline,
+			filename:    "avatar",
+			content:     png,
+			disposition: `inline; filename=avatar`,
+			contentType: "image/png",
+		},
+		{
+			name:        "non-ascii filename",
+			serve:       web.Download,
+			filename:    "résumé.html",
+			content:     []byte("<p>hello</p>"),
+			disposition: `attachment; filename*=utf-8''r%C3%A9sum%C3%A9.html`,
+			contentType: "text/html; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/files", nil)
+			if err := tt.serve(rec, req, tt.filename, bytes.NewReader(tt.content)); err != nil {
+				t.Fatalf("serve: %v", err)
+			}
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("status = %d, want 200", rec.Code)
+			}
+			if got := rec.Header().Get("Content-Disposition"); got != tt.disposition {
+				t.Errorf("Content-Disposition = %q, want %q", got, tt.disposition)
+			}
+			if got := rec.Header().Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+			if got := rec.Header().Get("Content-Length"); got != strconv.Itoa(len(tt.content)) {
+				t.Errorf("Content-Length = %q, want %d", got, len(tt.content))
+			}
+			if !bytes.Equal(rec.Body.Bytes(), tt.content) {
+				t.Errorf("body = %q, want %q", rec.Body.Bytes(), tt.content)
+			}
+		})
+	}
+}
+
+func TestDownload_Range(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/files", nil)
+	req.Header.Set("Range", "bytes=2-5")
+	if err := web.Download(rec, req, "data.txt", strings.NewReader("0123456789")); err != nil {
+		t.Fatalf("Download: %v", err)
+	}
+
+	if rec.Code != http.StatusPartialContent {
+		t.Fatalf("status = %d, want 206", rec.Code)
+	}
+	if got := rec.Header().Get("Content-Range"); got != "bytes 2-5/10" {
+		t.Errorf("Content-Range = %q", got)
+	}
+	if rec.Body.String() != "2345" {
+		t.Errorf("body = %q, want %q", rec.Body.String(), "2345")
+	}
+}
+
+// unseekable fails every Seek, like a closed file.
+type unseekable struct{ io.Reader }
+
+func (unseekable) Seek(int64, int) (int64, error) { return 0, errors.New("file already closed") }
+
+func TestDownload_SeekError(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/files", nil)
+	if err := web.Inline(rec, req, "broken.txt", unseekable{strings.NewReader("data")}); err == nil {
+		t.Fatal("Inline with an unseekable reader returned nil")
+	}
+	if len(rec.Header()) != 0 || rec.Body.Len() != 0 {
+		t.Errorf("response written: header = %v, body = %q", rec.Header(), rec.Body.String())
+	}
+}
+
+type xmlNote struct {
+	XMLName xml.Name `xml:"note"`
+	ID      int      `xml:"id,attr"`
+	Body    string   `xml:",cdata"`
+}
+
+func TestXML(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	web.XMLStatus(rec, http.StatusCreated, xmlNote{ID: 7, Body: "a < b & c"})
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("status = %d, want 201", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/xml; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	want := xml.Header + `<note id="7"><![CDATA[a < b & c]]></note>`
+	if rec.Body.String() != want {
+		t.Errorf("body = %q, want %q", rec.Body.String(), want)
+	}
+}
+
+func TestXMLStatusE_EncodeError(t *testing.T) {
+	t.Parallel()
+
+	rec := httptest.NewRecorder()
+	if err := web.XMLStatusE(rec, http.StatusOK, map[string]string{"a": "b"}); err == nil {
+		t.Fatal("expected error for unsupported map type")
+	}
+}
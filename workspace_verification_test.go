@@ -34,13 +34,22 @@ func TestWorkspaceUsesModulesWithoutRedundantReplacements(t *testing.T) {
 	}
 }
 
+// standaloneModules are released but kept out of go.work because they require
+// sibling modules at the pending release version; the verifier checks them in
+// a copy that replaces those siblings with the checkout.
+var standaloneModules = []string{"testutil"}
+
 func TestWorkspaceReleaseAndDocsModuleInventoriesAgree(t *testing.T) {
 	t.Parallel()
 	workspace := parseGoWorkModules(readRepoFile(t, "go.work"))
 	for i := range workspace {
 		workspace[i] = strings.TrimPrefix(workspace[i], "./")
+		if slices.Contains(standaloneModules, workspace[i]) {
+			t.Fatalf("go.work uses standalone module %s, whose pending sibling versions do not resolve", workspace[i])
+		}
 	}
 	workspace = slices.DeleteFunc(workspace, func(module string) bool { return module == "." })
+	workspace = append(workspace, standaloneModules...)
 	slices.Sort(workspace)
 
 	just := readRepoFile(t, "justfile")
@@ -111,6 +120,7 @@ func TestStandaloneModulesAreVerifiedWithoutWorkspaceReplacements(t *testing.T)
 		"GOWORK=off go test -mod=readonly ./...",
 		"verify_standalone_examples",
 		"verify_generated_projects",
+		"verify_standalone_testutil",
 		"compile_ignored_examples\n        verify_standalone_modules",
 	}
 	for _, fragment := range required {